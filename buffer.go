@@ -0,0 +1,177 @@
+package ll
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/olekukonko/ll/lh"
+	"github.com/olekukonko/ll/lx"
+)
+
+// BufferOption configures a RequestBuffer.
+type BufferOption func(*RequestBuffer)
+
+// WithBufferSlow flushes the buffer when the unit of work took at least d, even if it
+// succeeded. Zero (the default) disables the threshold.
+func WithBufferSlow(d time.Duration) BufferOption {
+	return func(b *RequestBuffer) {
+		b.slow = d
+	}
+}
+
+// WithBufferTrigger sets the least severe level that marks the unit of work as failed
+// when an entry at that level is logged, using the order Debug < Info < Warn < Error
+// < Fatal (default Error).
+func WithBufferTrigger(level lx.LevelType) BufferOption {
+	return func(b *RequestBuffer) {
+		if s := level.Severity(); s >= 0 {
+			b.trigger = s
+		}
+	}
+}
+
+// WithBufferLevel sets the minimum level captured by the buffered logger, independently
+// of the parent logger's level (default Debug), so a failed unit of work is written with
+// full detail.
+func WithBufferLevel(level lx.LevelType) BufferOption {
+	return func(b *RequestBuffer) {
+		b.level = level
+	}
+}
+
+// WithBufferCapacity bounds the buffer to the most recent n entries, like
+// lh.WithMemoryCapacity. Zero (the default) keeps every entry.
+func WithBufferCapacity(n int) BufferOption {
+	return func(b *RequestBuffer) {
+		b.capacity = n
+	}
+}
+
+// RequestBuffer holds the entries of one unit of work, such as a request or a job,
+// until it ends. A successful, fast unit of work discards them; a failed or slow one
+// writes all of them to the logger's handler, in order and with their original
+// timestamps. This is tail-based sampling: full detail is kept exactly for the
+// requests that need it. Thread-safe.
+type RequestBuffer struct {
+	slow     time.Duration
+	trigger  int          // Severity of the trigger level
+	level    lx.LevelType // Minimum level captured
+	capacity int
+
+	logger  *Logger           // Child logger writing into the buffer
+	sink    lx.Handler        // Handler the entries are flushed to
+	parent  lx.LevelType      // Parent logger's level, applied to entries after End
+	memory  *lh.MemoryHandler // Buffered entries
+	start   time.Time
+	failed  atomic.Bool
+	mu      sync.Mutex // Orders late entries after the flush
+	ended   bool
+	flushed bool
+}
+
+// Buffer starts buffering the entries of one unit of work. Entries logged through the
+// returned buffer's Logger, or through FromContext with the returned context, are held
+// in memory until End decides whether to write them. The buffered logger carries the
+// trace IDs of the span in ctx, as with Ctx, and captures every level from Debug up
+// whatever the parent's level; see WithBufferLevel.
+// Example:
+//
+//	func handle(w http.ResponseWriter, r *http.Request) {
+//	    ctx, buf := logger.Buffer(r.Context(), ll.WithBufferSlow(time.Second))
+//	    buf.Logger().Debug("loading cart") // Written only if the request fails or is slow
+//	    err := checkout(ctx)
+//	    buf.End(err)
+//	}
+func (l *Logger) Buffer(ctx context.Context, opts ...BufferOption) (context.Context, *RequestBuffer) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	b := &RequestBuffer{
+		trigger: lx.LevelError.Severity(),
+		level:   lx.LevelDebug,
+		start:   time.Now(),
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	b.memory = lh.NewMemoryHandler(lh.WithMemoryCapacity(b.capacity))
+	b.sink = l.GetHandler()
+	b.parent = l.GetLevel()
+	// The buffer decides what is written, so the child captures from its own level and
+	// entries never go to the parent's recorder instead of the buffer.
+	b.logger = l.Ctx(ctx).Context(nil).Level(b.level).Recorder(nil)
+	b.logger.handler = &bufferHandler{buffer: b}
+	return NewContext(ctx, b.logger), b
+}
+
+// Logger returns the logger writing into the buffer.
+func (b *RequestBuffer) Logger() *Logger {
+	return b.logger
+}
+
+// Fail marks the unit of work as failed, so End flushes the buffer.
+func (b *RequestBuffer) Fail() {
+	b.failed.Store(true)
+}
+
+// Failed reports whether the unit of work has been marked as failed, by Fail or by an
+// entry at or above the trigger level.
+func (b *RequestBuffer) Failed() bool {
+	return b.failed.Load()
+}
+
+// Len returns the number of entries currently buffered.
+func (b *RequestBuffer) Len() int {
+	return b.memory.Len()
+}
+
+// Dropped returns the number of entries evicted because the buffer was full.
+func (b *RequestBuffer) Dropped() uint64 {
+	return b.memory.Dropped()
+}
+
+// End finishes the unit of work. The buffered entries are written if err is non-nil,
+// the buffer was marked as failed, or the slow threshold was reached; otherwise they
+// are discarded. It reports whether they were written. Entries logged after End are
+// written directly if the parent logger's level admits them. Calling End again has no effect and returns the first result.
+func (b *RequestBuffer) End(err error) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.ended {
+		return b.flushed
+	}
+	b.ended = true
+	b.flushed = err != nil || b.failed.Load() || (b.slow > 0 && time.Since(b.start) >= b.slow)
+	if b.flushed && b.sink != nil {
+		for _, e := range b.memory.Entries() {
+			_ = b.sink.Handle(e)
+		}
+	}
+	b.memory.Reset()
+	return b.flushed
+}
+
+// bufferHandler collects the entries of a RequestBuffer.
+type bufferHandler struct {
+	buffer *RequestBuffer
+}
+
+// Handle buffers the entry, marking the unit of work as failed if it is at or above the
+// trigger level. After End, the entry is written directly, subject to the parent's level.
+func (h *bufferHandler) Handle(e *lx.Entry) error {
+	b := h.buffer
+	if s := e.Level.Severity(); s >= 0 && s >= b.trigger {
+		b.failed.Store(true)
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.ended {
+		if b.sink == nil || belowLevel(e.Level, b.parent) {
+			return nil
+		}
+		return b.sink.Handle(e)
+	}
+	return b.memory.Handle(e) // Clones the pooled entry
+}
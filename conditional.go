@@ -1,6 +1,7 @@
 package ll
 
 import (
+	"errors"
 	"sync"
 )
 
@@ -18,6 +19,7 @@ var conditionalPool = sync.Pool{
 type Conditional struct {
 	logger    *Logger // Associated logger instance for logging operations
 	condition bool    // Whether logging is allowed (true to log, false to skip)
+	err       error   // Error from IfErr checks, attached to logged entries as Entry.Error
 }
 
 // getConditional retrieves a Conditional from the pool or creates a new one.
@@ -25,6 +27,7 @@ func getConditional(logger *Logger, condition bool) *Conditional {
 	c := conditionalPool.Get().(*Conditional)
 	c.logger = logger
 	c.condition = condition
+	c.err = nil
 	return c
 }
 
@@ -32,6 +35,7 @@ func getConditional(logger *Logger, condition bool) *Conditional {
 func putConditional(c *Conditional) {
 	c.logger = nil
 	c.condition = false
+	c.err = nil
 	conditionalPool.Put(c)
 }
 
@@ -81,7 +85,9 @@ func (cl *Conditional) IfAny(conditions ...bool) *Conditional {
 //	err := doSomething()
 //	logger.IfErr(err).Error("Operation failed") // Only logs if err != nil
 func (l *Logger) IfErr(err error) *Conditional {
-	return l.If(err != nil)
+	c := l.If(err != nil)
+	c.err = err
+	return c
 }
 
 // IfErrAny creates a conditional logger that logs only if AT LEAST ONE error is non-nil.
@@ -95,7 +101,9 @@ func (l *Logger) IfErr(err error) *Conditional {
 func (l *Logger) IfErrAny(errs ...error) *Conditional {
 	for _, err := range errs {
 		if err != nil {
-			return l.If(true) // Any non-nil error makes it true
+			c := l.If(true) // Any non-nil error makes it true
+			c.err = joinNonNil(errs)
+			return c
 		}
 	}
 	return l.If(false) // False only if all errors are nil
@@ -115,7 +123,9 @@ func (l *Logger) IfErrOne(errs ...error) *Conditional {
 			return l.If(false) // Any nil error makes it false
 		}
 	}
-	return l.If(len(errs) > 0) // True only if we have at least one error and all are non-nil
+	c := l.If(len(errs) > 0) // True only if we have at least one error and all are non-nil
+	c.err = joinNonNil(errs)
+	return c
 }
 
 // IfErr creates a conditional logger that logs only if the error is non-nil.
@@ -125,6 +135,7 @@ func (l *Logger) IfErrOne(errs ...error) *Conditional {
 //	err := doSomething()
 //	logger.If(true).IfErr(err).Error("Failed") // Only logs if condition true AND err != nil
 func (cl *Conditional) IfErr(err error) *Conditional {
+	cl.attach(err)
 	return cl.IfOne(err != nil)
 }
 
@@ -138,6 +149,7 @@ func (cl *Conditional) IfErr(err error) *Conditional {
 func (cl *Conditional) IfErrAny(errs ...error) *Conditional {
 	for _, err := range errs {
 		if err != nil {
+			cl.attach(joinNonNil(errs))
 			// Reuse if condition already true
 			if cl.condition {
 				return cl
@@ -166,6 +178,7 @@ func (cl *Conditional) IfErrOne(errs ...error) *Conditional {
 	}
 	if len(errs) > 0 {
 		cl.condition = cl.condition && true
+		cl.attach(joinNonNil(errs))
 	}
 	return cl
 }
@@ -211,7 +224,7 @@ func (cl *Conditional) Debug(args ...any) {
 		return
 	}
 	// Delegate to logger's Debug method
-	cl.logger.Debug(args...)
+	cl.target().Debug(args...)
 }
 
 // Debugf logs a message at Debug level with a format string if the condition is true.
@@ -228,7 +241,7 @@ func (cl *Conditional) Debugf(format string, args ...any) {
 		return
 	}
 	// Delegate to logger's Debugf method
-	cl.logger.Debugf(format, args...)
+	cl.target().Debugf(format, args...)
 }
 
 // Error logs a message at Error level with variadic arguments if the condition is true.
@@ -245,7 +258,7 @@ func (cl *Conditional) Error(args ...any) {
 		return
 	}
 	// Delegate to logger's Error method
-	cl.logger.Error(args...)
+	cl.target().Error(args...)
 }
 
 // Errorf logs a message at Error level with a format string if the condition is true.
@@ -262,7 +275,7 @@ func (cl *Conditional) Errorf(format string, args ...any) {
 		return
 	}
 	// Delegate to logger's Errorf method
-	cl.logger.Errorf(format, args...)
+	cl.target().Errorf(format, args...)
 }
 
 // Fatal logs a message at Error level with a stack trace and variadic arguments if the condition is true,
@@ -280,7 +293,7 @@ func (cl *Conditional) Fatal(args ...any) {
 		return
 	}
 	// Delegate to logger's Fatal method
-	cl.logger.Fatal(args...)
+	cl.target().Fatal(args...)
 }
 
 // Fatalf logs a formatted message at Error level with a stack trace if the condition is true, then exits.
@@ -297,7 +310,7 @@ func (cl *Conditional) Fatalf(format string, args ...any) {
 		return
 	}
 	// Delegate to logger's Fatalf method
-	cl.logger.Fatalf(format, args...)
+	cl.target().Fatalf(format, args...)
 }
 
 // Field starts a fluent chain for adding fields from a map, if the condition is true.
@@ -315,7 +328,9 @@ func (cl *Conditional) Field(fields map[string]interface{}) *FieldBuilder {
 		return &FieldBuilder{logger: nil, fields: nil}
 	}
 	// Delegate to logger's Field method
-	return cl.logger.Field(fields)
+	fb := cl.logger.Field(fields)
+	fb.err = cl.err
+	return fb
 }
 
 // Fields starts a fluent chain for adding fields using variadic key-value pairs, if the condition is true.
@@ -333,7 +348,9 @@ func (cl *Conditional) Fields(pairs ...any) *FieldBuilder {
 		return &FieldBuilder{logger: nil, fields: nil}
 	}
 	// Delegate to logger's Fields method
-	return cl.logger.Fields(pairs...)
+	fb := cl.logger.Fields(pairs...)
+	fb.err = cl.err
+	return fb
 }
 
 // Info logs a message at Info level with variadic arguments if the condition is true.
@@ -351,7 +368,7 @@ func (cl *Conditional) Info(args ...any) {
 		return
 	}
 	// Delegate to logger's Info method
-	cl.logger.Info(args...)
+	cl.target().Info(args...)
 }
 
 // Infof logs a message at Info level with a format string if the condition is true.
@@ -369,7 +386,7 @@ func (cl *Conditional) Infof(format string, args ...any) {
 		return
 	}
 	// Delegate to logger's Infof method
-	cl.logger.Infof(format, args...)
+	cl.target().Infof(format, args...)
 }
 
 // Panic logs a message at Error level with a stack trace and variadic arguments if the condition is true,
@@ -386,7 +403,7 @@ func (cl *Conditional) Panic(args ...any) {
 		return
 	}
 	// Delegate to logger's Panic method
-	cl.logger.Panic(args...)
+	cl.target().Panic(args...)
 }
 
 // Panicf logs a formatted message at Error level with a stack trace if the condition is true, then panics.
@@ -403,7 +420,7 @@ func (cl *Conditional) Panicf(format string, args ...any) {
 		return
 	}
 	// Delegate to logger's Panicf method
-	cl.logger.Panicf(format, args...)
+	cl.target().Panicf(format, args...)
 }
 
 // Stack logs a message at Error level with a stack trace and variadic arguments if the condition is true.
@@ -420,7 +437,7 @@ func (cl *Conditional) Stack(args ...any) {
 		return
 	}
 	// Delegate to logger's Stack method
-	cl.logger.Stack(args...)
+	cl.target().Stack(args...)
 }
 
 // Stackf logs a message at Error level with a stack trace and a format string if the condition is true.
@@ -437,7 +454,7 @@ func (cl *Conditional) Stackf(format string, args ...any) {
 		return
 	}
 	// Delegate to logger's Stackf method
-	cl.logger.Stackf(format, args...)
+	cl.target().Stackf(format, args...)
 }
 
 // Warn logs a message at Warn level with variadic arguments if the condition is true.
@@ -454,7 +471,7 @@ func (cl *Conditional) Warn(args ...any) {
 		return
 	}
 	// Delegate to logger's Warn method
-	cl.logger.Warn(args...)
+	cl.target().Warn(args...)
 }
 
 // Warnf logs a message at Warn level with a format string if the condition is true.
@@ -471,5 +488,60 @@ func (cl *Conditional) Warnf(format string, args ...any) {
 		return
 	}
 	// Delegate to logger's Warnf method
-	cl.logger.Warnf(format, args...)
+	cl.target().Warnf(format, args...)
+}
+
+// attach records err as the conditional's error, joining it with any error already attached.
+func (cl *Conditional) attach(err error) {
+	if err == nil {
+		return
+	}
+	if cl.err == nil {
+		cl.err = err
+		return
+	}
+	cl.err = errors.Join(cl.err, err)
+}
+
+// conditionalTarget is the logging surface shared by Logger and FieldBuilder.
+type conditionalTarget interface {
+	Debug(args ...any)
+	Debugf(format string, args ...any)
+	Info(args ...any)
+	Infof(format string, args ...any)
+	Warn(args ...any)
+	Warnf(format string, args ...any)
+	Error(args ...any)
+	Errorf(format string, args ...any)
+	Stack(args ...any)
+	Stackf(format string, args ...any)
+	Fatal(args ...any)
+	Fatalf(format string, args ...any)
+	Panic(args ...any)
+	Panicf(format string, args ...any)
+}
+
+// target returns the logger, or a FieldBuilder carrying the conditional's error so that
+// it is attached to the logged entry.
+func (cl *Conditional) target() conditionalTarget {
+	if cl.err == nil {
+		return cl.logger
+	}
+	fb := getFieldBuilder(cl.logger, 0)
+	fb.err = cl.err
+	return fb
+}
+
+// joinNonNil joins the non-nil errors in errs, returning nil if there are none.
+func joinNonNil(errs []error) error {
+	var nonNil []error
+	for _, err := range errs {
+		if err != nil {
+			nonNil = append(nonNil, err)
+		}
+	}
+	if len(nonNil) == 0 {
+		return nil
+	}
+	return joinErrors(nonNil)
 }
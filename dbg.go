@@ -125,7 +125,7 @@ func nthLine(lines []string, n int) (string, bool) {
 //	logger.Dbg("val", x)
 //	Output: [file.go:123] "val" = "val", x = 42
 func (l *Logger) Dbg(values ...interface{}) {
-	if !l.shouldLog(lx.LevelInfo) {
+	if !l.shouldHandle(lx.LevelInfo) {
 		return
 	}
 	l.dbg(2, values...)
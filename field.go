@@ -26,12 +26,14 @@ var fieldBuilderPool = sync.Pool{
 type FieldBuilder struct {
 	logger *Logger   // Associated logger instance for logging operations
 	fields lx.Fields // Fields to include in the log entry as ordered key-value pairs
+	err    error     // Error attached to logged entries as Entry.Error
 }
 
 // getFieldBuilder retrieves a FieldBuilder from the pool or creates a new one.
 func getFieldBuilder(logger *Logger, capacity int) *FieldBuilder {
 	fb := fieldBuilderPool.Get().(*FieldBuilder)
 	fb.logger = logger
+	fb.err = nil
 	// Ensure minimum capacity to reduce small allocations
 	const minFieldCapacity = 4
 	if capacity < minFieldCapacity {
@@ -49,6 +51,7 @@ func getFieldBuilder(logger *Logger, capacity int) *FieldBuilder {
 func putFieldBuilder(fb *FieldBuilder) {
 	fb.logger = nil
 	fb.fields = fb.fields[:0]
+	fb.err = nil
 	fieldBuilderPool.Put(fb)
 }
 
@@ -86,7 +89,7 @@ func (fb *FieldBuilder) Info(args ...any) {
 	if fb.logger == nil {
 		return
 	}
-	fb.logger.log(lx.LevelInfo, lx.ClassText, cat.Space(args...), fb.fields, false)
+	fb.logger.logErr(lx.LevelInfo, lx.ClassText, cat.Space(args...), fb.fields, fb.err, false)
 	putFieldBuilder(fb)
 }
 
@@ -102,7 +105,7 @@ func (fb *FieldBuilder) Infof(format string, args ...any) {
 		return
 	}
 	msg := fmt.Sprintf(format, args...)
-	fb.logger.log(lx.LevelInfo, lx.ClassText, msg, fb.fields, false)
+	fb.logger.logErr(lx.LevelInfo, lx.ClassText, msg, fb.fields, fb.err, false)
 	putFieldBuilder(fb)
 }
 
@@ -117,7 +120,7 @@ func (fb *FieldBuilder) Debug(args ...any) {
 	if fb.logger == nil {
 		return
 	}
-	fb.logger.log(lx.LevelDebug, lx.ClassText, cat.Space(args...), fb.fields, false)
+	fb.logger.logErr(lx.LevelDebug, lx.ClassText, cat.Space(args...), fb.fields, fb.err, false)
 	putFieldBuilder(fb)
 }
 
@@ -133,7 +136,7 @@ func (fb *FieldBuilder) Debugf(format string, args ...any) {
 		return
 	}
 	msg := fmt.Sprintf(format, args...)
-	fb.logger.log(lx.LevelDebug, lx.ClassText, msg, fb.fields, false)
+	fb.logger.logErr(lx.LevelDebug, lx.ClassText, msg, fb.fields, fb.err, false)
 	putFieldBuilder(fb)
 }
 
@@ -148,7 +151,7 @@ func (fb *FieldBuilder) Warn(args ...any) {
 	if fb.logger == nil {
 		return
 	}
-	fb.logger.log(lx.LevelWarn, lx.ClassText, cat.Space(args...), fb.fields, false)
+	fb.logger.logErr(lx.LevelWarn, lx.ClassText, cat.Space(args...), fb.fields, fb.err, false)
 	putFieldBuilder(fb)
 }
 
@@ -164,7 +167,7 @@ func (fb *FieldBuilder) Warnf(format string, args ...any) {
 		return
 	}
 	msg := fmt.Sprintf(format, args...)
-	fb.logger.log(lx.LevelWarn, lx.ClassText, msg, fb.fields, false)
+	fb.logger.logErr(lx.LevelWarn, lx.ClassText, msg, fb.fields, fb.err, false)
 	putFieldBuilder(fb)
 }
 
@@ -179,7 +182,7 @@ func (fb *FieldBuilder) Error(args ...any) {
 	if fb.logger == nil {
 		return
 	}
-	fb.logger.log(lx.LevelError, lx.ClassText, cat.Space(args...), fb.fields, false)
+	fb.logger.logErr(lx.LevelError, lx.ClassText, cat.Space(args...), fb.fields, fb.err, false)
 	putFieldBuilder(fb)
 }
 
@@ -195,7 +198,7 @@ func (fb *FieldBuilder) Errorf(format string, args ...any) {
 		return
 	}
 	msg := fmt.Sprintf(format, args...)
-	fb.logger.log(lx.LevelError, lx.ClassText, msg, fb.fields, false)
+	fb.logger.logErr(lx.LevelError, lx.ClassText, msg, fb.fields, fb.err, false)
 	putFieldBuilder(fb)
 }
 
@@ -210,7 +213,7 @@ func (fb *FieldBuilder) Stack(args ...any) {
 	if fb.logger == nil {
 		return
 	}
-	fb.logger.log(lx.LevelError, lx.ClassText, cat.Space(args...), fb.fields, true)
+	fb.logger.logErr(lx.LevelError, lx.ClassText, cat.Space(args...), fb.fields, fb.err, true)
 	putFieldBuilder(fb)
 }
 
@@ -226,7 +229,7 @@ func (fb *FieldBuilder) Stackf(format string, args ...any) {
 		return
 	}
 	msg := fmt.Sprintf(format, args...)
-	fb.logger.log(lx.LevelError, lx.ClassText, msg, fb.fields, true)
+	fb.logger.logErr(lx.LevelError, lx.ClassText, msg, fb.fields, fb.err, true)
 	putFieldBuilder(fb)
 }
 
@@ -248,7 +251,7 @@ func (fb *FieldBuilder) Fatal(args ...any) {
 		}
 		builder.WriteString(fmt.Sprint(arg))
 	}
-	fb.logger.log(lx.LevelFatal, lx.ClassText, builder.String(), fb.fields, fb.logger.fatalStack)
+	fb.logger.logErr(lx.LevelFatal, lx.ClassText, builder.String(), fb.fields, fb.err, fb.logger.fatalStack)
 	if fb.logger.fatalExits {
 		os.Exit(1)
 	}
@@ -287,7 +290,7 @@ func (fb *FieldBuilder) Panic(args ...any) {
 		builder.WriteString(fmt.Sprint(arg))
 	}
 	msg := builder.String()
-	fb.logger.log(lx.LevelError, lx.ClassText, msg, fb.fields, true)
+	fb.logger.logErr(lx.LevelError, lx.ClassText, msg, fb.fields, fb.err, true)
 	panic(msg)
 }
 
@@ -304,9 +307,10 @@ func (fb *FieldBuilder) Panicf(format string, args ...any) {
 	fb.Panic(fmt.Sprintf(format, args...))
 }
 
-// Err adds one or more errors to the FieldBuilder as a field and logs them.
-// It stores non-nil errors in the "error" field: a single error if only one is non-nil,
-// or a slice of errors if multiple are non-nil. Returns the FieldBuilder for chaining.
+// Err logs one or more errors and attaches them to the FieldBuilder.
+// Non-nil errors are logged at Error level, and set as Entry.Error (joined with
+// errors.Join if there are several) on that entry and on the entries logged later in
+// the chain. Returns the FieldBuilder for chaining.
 // Example:
 //
 //	logger := New("app").Enable()
@@ -331,12 +335,9 @@ func (fb *FieldBuilder) Err(errs ...error) *FieldBuilder {
 		}
 	}
 	if count > 0 {
-		if count == 1 {
-			fb.fields = append(fb.fields, lx.Field{Key: "error", Value: nonNilErrors[0]})
-		} else {
-			fb.fields = append(fb.fields, lx.Field{Key: "error", Value: nonNilErrors})
-		}
-		fb.logger.log(lx.LevelError, lx.ClassText, builder.String(), nil, false)
+		// Attach the errors to this entry and to entries logged later in the chain
+		fb.err = joinErrors(nonNilErrors)
+		fb.logger.logErr(lx.LevelError, lx.ClassText, builder.String(), nil, fb.err, false)
 	}
 	return fb
 }
@@ -368,3 +369,40 @@ func (fb *FieldBuilder) Merge(pairs ...any) *FieldBuilder {
 	}
 	return fb
 }
+
+// Group adds a group field nesting the given key-value pairs under name, so that
+// related attributes render as {"http":{"method":"GET"}} in JSON and as
+// http.method=GET in text. Pairs may mix key-value arguments with lx.Field values
+// (including nested groups). Returns the FieldBuilder for chaining.
+// Example:
+//
+//	logger := New("app").Enable()
+//	logger.Fields("user", "alice").Group("http", "method", "GET", "status", 200).Info("Served")
+//	// Output: [app] INFO: Served [user=alice http.method=GET http.status=200]
+func (fb *FieldBuilder) Group(name string, pairs ...any) *FieldBuilder {
+	group := make(lx.Fields, 0, len(pairs)/2)
+	for i := 0; i < len(pairs); i++ {
+		if f, ok := pairs[i].(lx.Field); ok {
+			group = append(group, f)
+			continue
+		}
+		if i == len(pairs)-1 {
+			group = append(group, lx.Field{
+				Key:   "error",
+				Value: fmt.Errorf("uneven key-value pairs in Group: [%v]", pairs[i]),
+			})
+			break
+		}
+		if key, ok := pairs[i].(string); ok {
+			group = append(group, lx.Field{Key: key, Value: pairs[i+1]})
+		} else {
+			group = append(group, lx.Field{
+				Key:   "error",
+				Value: fmt.Errorf("non-string key in Group: %v", pairs[i]),
+			})
+		}
+		i++
+	}
+	fb.fields = append(fb.fields, lx.Group(name, group...))
+	return fb
+}
@@ -525,15 +525,14 @@ func Clone() *Logger {
 	return defaultLogger.Clone()
 }
 
-// Err adds one or more errors to the default logger’s context and logs them.
-// It stores non-nil errors in the "error" context field and logs their concatenated string
-// representations (e.g., "failed 1; failed 2") at the Error level. Thread-safe via the Logger’s mutex.
+// Err logs one or more errors at Error level using the default logger.
+// Non-nil errors are logged as their concatenated string representations (e.g.,
+// "failed 1; failed 2") and attached to the entry as Entry.Error. The default logger's
+// context is not modified. Thread-safe.
 // Example:
 //
 //	err1 := errors.New("failed 1")
-//	ll.Err(err1)
-//	ll.Info("Error occurred") // Output: [] ERROR: failed 1
-//	                         //         [] INFO: Error occurred [error=failed 1]
+//	ll.Err(err1) // Output: [] ERROR: failed 1
 func Err(errs ...error) {
 	defaultLogger.Err(errs...)
 }
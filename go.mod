@@ -3,7 +3,7 @@ module github.com/olekukonko/ll
 go 1.21
 
 require (
-	github.com/cespare/xxhash/v2 v2.3.0 // indirect
-	github.com/goccy/go-json v0.10.5 // indirect
-	github.com/olekukonko/cat v0.0.0-20250911104152-50322a0618f6 // indirect
+	github.com/cespare/xxhash/v2 v2.3.0
+	github.com/goccy/go-json v0.10.5
+	github.com/olekukonko/cat v0.0.0-20250911104152-50322a0618f6
 )
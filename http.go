@@ -0,0 +1,231 @@
+package ll
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/olekukonko/ll/lx"
+)
+
+// HTTPOption configures HTTPMiddleware.
+type HTTPOption func(*httpAccess)
+
+// WithHTTPRoute sets a function returning the route pattern that matched a request
+// (e.g. "/users/{id}"), logged as the "route" field. Without it, no route is logged.
+func WithHTTPRoute(fn func(r *http.Request) string) HTTPOption {
+	return func(a *httpAccess) {
+		a.route = fn
+	}
+}
+
+// WithHTTPLevels sets the function mapping a response status to the level of its entry.
+// The default logs 5xx at Error, 4xx at Warn and everything else at Info.
+func WithHTTPLevels(fn func(status int) lx.LevelType) HTTPOption {
+	return func(a *httpAccess) {
+		if fn != nil {
+			a.level = fn
+		}
+	}
+}
+
+// WithHTTPSkipPaths disables logging for requests to the given paths, such as health checks.
+func WithHTTPSkipPaths(paths ...string) HTTPOption {
+	return func(a *httpAccess) {
+		for _, p := range paths {
+			a.skipPaths[p] = struct{}{}
+		}
+	}
+}
+
+// WithHTTPSkip disables logging for requests for which skip returns true.
+func WithHTTPSkip(skip func(r *http.Request) bool) HTTPOption {
+	return func(a *httpAccess) {
+		a.skip = skip
+	}
+}
+
+// WithHTTPSlowThreshold logs requests taking longer than d at Warn level or above,
+// with a "slow" field set to true.
+func WithHTTPSlowThreshold(d time.Duration) HTTPOption {
+	return func(a *httpAccess) {
+		a.slow = d
+	}
+}
+
+// httpAccess holds the HTTPMiddleware configuration.
+type httpAccess struct {
+	logger    *Logger
+	route     func(r *http.Request) string
+	level     func(status int) lx.LevelType
+	skipPaths map[string]struct{}
+	skip      func(r *http.Request) bool
+	slow      time.Duration
+}
+
+// HTTPMiddleware returns net/http middleware that logs one entry per request, with
+// the message "METHOD path" and the fields method, path, query (if any), route (see
+// WithHTTPRoute), proto, status, bytes_in (request body bytes read), bytes_out,
+// duration, remote_addr, user_agent and referer (if any). Render them in Apache
+// Common or Combined format with lh.NewAccessLogHandler.
+//
+// Entries are logged through logger.Ctx, so placing the middleware inside
+// TraceMiddleware adds the request's trace_id and span_id.
+// Example:
+//
+//	mux := http.NewServeMux()
+//	handler := ll.HTTPMiddleware(logger,
+//	    ll.WithHTTPSkipPaths("/healthz"),
+//	    ll.WithHTTPSlowThreshold(time.Second),
+//	)(mux)
+//	http.ListenAndServe(":8080", ll.TraceMiddleware(logger)(handler))
+func HTTPMiddleware(logger *Logger, opts ...HTTPOption) func(http.Handler) http.Handler {
+	a := &httpAccess{
+		logger:    logger,
+		level:     httpLevel,
+		skipPaths: make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if _, ok := a.skipPaths[r.URL.Path]; ok || (a.skip != nil && a.skip(r)) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			start := time.Now()
+			body := &countingBody{ReadCloser: r.Body}
+			if r.Body != nil && r.Body != http.NoBody {
+				r.Body = body
+			}
+			rw := &responseRecorder{ResponseWriter: w}
+			next.ServeHTTP(rw, r)
+			a.log(r, rw, body.n, time.Since(start))
+		})
+	}
+}
+
+// log writes the access entry for a completed request.
+func (a *httpAccess) log(r *http.Request, rw *responseRecorder, bytesIn int64, duration time.Duration) {
+	// Suspended loggers write nothing, as with the logging methods
+	if a.logger.suspend.Load() {
+		return
+	}
+	status := rw.status
+	if status == 0 {
+		status = http.StatusOK
+	}
+
+	level := a.level(status)
+	slow := a.slow > 0 && duration > a.slow
+	if slow && (level == lx.LevelInfo || level == lx.LevelDebug) {
+		level = lx.LevelWarn
+	}
+
+	fields := make(lx.Fields, 0, 14)
+	fields = append(fields, lx.String("method", r.Method), lx.String("path", r.URL.Path))
+	if r.URL.RawQuery != "" {
+		fields = append(fields, lx.String("query", r.URL.RawQuery))
+	}
+	if a.route != nil {
+		fields = append(fields, lx.String("route", a.route(r)))
+	}
+	fields = append(fields,
+		lx.String("proto", r.Proto),
+		lx.Int("status", status),
+		lx.Int64("bytes_in", bytesIn),
+		lx.Int64("bytes_out", rw.bytes),
+		lx.Duration("duration", duration),
+		lx.String("remote_addr", r.RemoteAddr),
+		lx.String("user_agent", r.UserAgent()),
+	)
+	if ref := r.Referer(); ref != "" {
+		fields = append(fields, lx.String("referer", ref))
+	}
+	if slow {
+		fields = append(fields, lx.Bool("slow", true))
+	}
+	a.logger.Ctx(r.Context()).log(level, lx.ClassText, r.Method+" "+r.URL.Path, fields, false)
+}
+
+// httpLevel maps 5xx responses to Error, 4xx to Warn and everything else to Info.
+func httpLevel(status int) lx.LevelType {
+	switch {
+	case status >= 500:
+		return lx.LevelError
+	case status >= 400:
+		return lx.LevelWarn
+	default:
+		return lx.LevelInfo
+	}
+}
+
+// countingBody counts the bytes read from a request body.
+type countingBody struct {
+	io.ReadCloser
+	n int64
+}
+
+// Read reads from the body, counting the bytes read.
+func (b *countingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.n += int64(n)
+	return n, err
+}
+
+// responseRecorder records the status and size of a response.
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+	bytes  int64
+}
+
+// WriteHeader records the status and sends it.
+func (rw *responseRecorder) WriteHeader(status int) {
+	if rw.status == 0 {
+		rw.status = status
+	}
+	rw.ResponseWriter.WriteHeader(status)
+}
+
+// Write counts the bytes written, recording an implicit 200 status.
+func (rw *responseRecorder) Write(p []byte) (int, error) {
+	if rw.status == 0 {
+		rw.status = http.StatusOK
+	}
+	n, err := rw.ResponseWriter.Write(p)
+	rw.bytes += int64(n)
+	return n, err
+}
+
+// Flush implements http.Flusher when the underlying writer does.
+func (rw *responseRecorder) Flush() {
+	if f, ok := rw.ResponseWriter.(http.Flusher); ok {
+		if rw.status == 0 {
+			rw.status = http.StatusOK
+		}
+		f.Flush()
+	}
+}
+
+// Hijack implements http.Hijacker when the underlying writer does.
+func (rw *responseRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := rw.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("ll: underlying ResponseWriter does not support hijacking")
+	}
+	if rw.status == 0 {
+		rw.status = http.StatusSwitchingProtocols
+	}
+	return h.Hijack()
+}
+
+// Unwrap returns the underlying writer, for http.ResponseController.
+func (rw *responseRecorder) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
@@ -34,7 +34,7 @@ func NewInspector(logger *Logger) *Inspector {
 //	o.Log(2, someStruct)
 func (o *Inspector) Log(skip int, values ...interface{}) {
 	// Skip if logger is suspended or Info level is disabled
-	if o.logger.suspend.Load() || !o.logger.shouldLog(lx.LevelInfo) {
+	if o.logger.suspend.Load() || !o.logger.shouldHandle(lx.LevelInfo) {
 		return
 	}
 
@@ -53,6 +53,7 @@ func (o *Inspector) Log(skip int, values ...interface{}) {
 
 	// Process each value individually
 	for _, value := range values {
+		value = lx.Resolve(value)
 		var jsonData []byte
 		var err error
 
@@ -130,6 +131,14 @@ func (o *Inspector) structToMap(val reflect.Value) map[string]interface{} {
 			field = field.Elem()
 		}
 
+		// Types with their own log representation are resolved instead of walked
+		if field.CanInterface() {
+			if lv, ok := field.Interface().(lx.LogValuer); ok {
+				result[fieldName] = lx.Resolve(lv)
+				continue
+			}
+		}
+
 		// Recurse for struct fields
 		if field.Kind() == reflect.Struct {
 			subMap := o.structToMap(field)
@@ -248,16 +248,7 @@ func (h *Syslog) formatMessage(e *lx.Entry) string {
 	// Add fields if present
 	if len(e.Fields) > 0 {
 		builder.WriteString(" [")
-		first := true
-		for _, f := range e.Fields {
-			if !first {
-				builder.WriteString(" ")
-			}
-			builder.WriteString(f.Key)
-			builder.WriteString("=")
-			builder.WriteString(fmt.Sprint(f.Value))
-			first = false
-		}
+		writeFields(&builder, e.Fields, "", true)
 		builder.WriteString("]")
 	}
 
@@ -269,3 +260,27 @@ func (h *Syslog) formatMessage(e *lx.Entry) string {
 
 	return builder.String()
 }
+
+// writeFields writes fields as space-separated key=value pairs, flattening groups
+// into dotted keys (e.g., http.method=GET). It returns whether the next pair is
+// still the first one written.
+func writeFields(builder *strings.Builder, fields lx.Fields, prefix string, first bool) bool {
+	for _, f := range fields {
+		key := f.Key
+		if prefix != "" {
+			key = prefix + "." + f.Key
+		}
+		if group, ok := f.GroupValue(); ok {
+			first = writeFields(builder, group, key, first)
+			continue
+		}
+		if !first {
+			builder.WriteString(" ")
+		}
+		builder.WriteString(key)
+		builder.WriteString("=")
+		builder.WriteString(fmt.Sprint(lx.Resolve(f.Interface())))
+		first = false
+	}
+	return first
+}
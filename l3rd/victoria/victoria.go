@@ -282,7 +282,11 @@ func (v *Victoria) buildLine(e *lx.Entry) map[string]interface{} {
 	// Add custom fields - e.Fields is a slice of key-value pairs
 	for _, field := range e.Fields {
 		key := field.Key
-		value := field.Value
+		field = field.Resolve()
+		value := field.Interface()
+		if group, ok := field.GroupValue(); ok {
+			value = group.Map() // Nested JSON object
+		}
 
 		// Apply field mapping if configured
 		if mapped, ok := v.config.FieldMap[key]; ok {
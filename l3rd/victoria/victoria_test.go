@@ -184,6 +184,33 @@ func TestHandle(t *testing.T) {
 					line["duration"].(float64) == 150
 			},
 		},
+		{
+			name: "log with field group",
+			entry: &lx.Entry{
+				Timestamp: time.Now(),
+				Level:     lx.LevelInfo,
+				Message:   "request served",
+				Namespace: "app.http",
+				Fields: []lx.Field{
+					lx.Group("http", lx.String("method", "GET"), lx.Int("status", 200)),
+					{Key: "method", Value: "internal"},
+				},
+			},
+			config: []Option{
+				WithFieldMapping("method", "rpc_method"),
+			},
+			expectError: false,
+			validate: func(data []byte) bool {
+				var line map[string]interface{}
+				if err := json.Unmarshal(data, &line); err != nil {
+					return false
+				}
+				http, ok := line["http"].(map[string]interface{})
+				return ok && http["method"] == "GET" && // Nested keys are not mapped
+					http["status"].(float64) == 200 &&
+					line["rpc_method"] == "internal"
+			},
+		},
 		{
 			name: "dev mode adds debug info",
 			entry: &lx.Entry{
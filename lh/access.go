@@ -0,0 +1,200 @@
+package lh
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/olekukonko/ll/lx"
+)
+
+// AccessFormat selects the Apache log format written by AccessLogHandler.
+type AccessFormat int
+
+const (
+	AccessCommon   AccessFormat = iota // host ident user [time] "request" status bytes
+	AccessCombined                     // Common, followed by "referer" "user-agent"
+)
+
+// accessTimeFormat is the Apache %t timestamp layout.
+const accessTimeFormat = "02/Jan/2006:15:04:05 -0700"
+
+// AccessLogHandler writes the request entries logged by ll.HTTPMiddleware as Apache
+// Common or Combined log lines. Entries without a "method" field are ignored, so it can
+// share a logger with other handlers through MultiHandler or Router.
+// Thread-safe if the underlying writer is thread-safe.
+type AccessLogHandler struct {
+	writer io.Writer
+	format AccessFormat
+	mu     sync.Mutex
+}
+
+// NewAccessLogHandler creates a handler writing request entries to w in format.
+// Example:
+//
+//	handler := lh.NewAccessLogHandler(os.Stdout, lh.AccessCombined)
+//	logger := ll.New("http").Enable().Handler(handler)
+//	http.ListenAndServe(":8080", ll.HTTPMiddleware(logger)(mux))
+//	// Output: 10.0.0.1 - - [02/Jan/2006:15:04:05 +0000] "GET /users?id=1 HTTP/1.1" 200 512 "-" "curl/8.0"
+func NewAccessLogHandler(w io.Writer, format AccessFormat) *AccessLogHandler {
+	return &AccessLogHandler{writer: w, format: format}
+}
+
+// Handle writes the entry as an access log line.
+func (h *AccessLogHandler) Handle(e *lx.Entry) error {
+	var method, path, query, proto, remote, referer, agent string
+	var status, bytesOut int64
+	var duration time.Duration
+	for _, f := range e.Fields {
+		switch f.Key {
+		case "method":
+			method = accessString(f)
+		case "path":
+			path = accessString(f)
+		case "query":
+			query = accessString(f)
+		case "proto":
+			proto = accessString(f)
+		case "remote_addr":
+			remote = accessString(f)
+		case "referer":
+			referer = accessString(f)
+		case "user_agent":
+			agent = accessString(f)
+		case "status":
+			status = accessInt(f)
+		case "bytes_out":
+			bytesOut = accessInt(f)
+		case "duration":
+			duration = time.Duration(accessInt(f))
+		}
+	}
+	if method == "" {
+		return nil
+	}
+
+	if host, _, err := net.SplitHostPort(remote); err == nil {
+		remote = host
+	}
+	// Apache logs the time the request was received.
+	ts := e.Timestamp
+	if ts.IsZero() {
+		ts = time.Now()
+	}
+	ts = ts.Add(-duration)
+
+	buf := textBufPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	defer textBufPool.Put(buf)
+
+	buf.WriteString(orDash(remote))
+	buf.WriteString(" - - [")
+	buf.WriteString(ts.Format(accessTimeFormat))
+	buf.WriteString(`] "`)
+	// Path and query are decoded, so they may hold quotes and newlines from the client.
+	writeAccessEscaped(buf, method)
+	buf.WriteByte(' ')
+	writeAccessEscaped(buf, path)
+	if query != "" {
+		buf.WriteByte('?')
+		writeAccessEscaped(buf, query)
+	}
+	if proto != "" {
+		buf.WriteByte(' ')
+		writeAccessEscaped(buf, proto)
+	}
+	buf.WriteString(`" `)
+	buf.WriteString(strconv.FormatInt(status, 10))
+	buf.WriteByte(' ')
+	if bytesOut > 0 {
+		buf.WriteString(strconv.FormatInt(bytesOut, 10))
+	} else {
+		buf.WriteByte('-')
+	}
+	if h.format == AccessCombined {
+		buf.WriteString(` "`)
+		writeAccessEscaped(buf, orDash(referer))
+		buf.WriteString(`" "`)
+		writeAccessEscaped(buf, orDash(agent))
+		buf.WriteByte('"')
+	}
+	buf.WriteByte('\n')
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	_, err := h.writer.Write(buf.Bytes())
+	return err
+}
+
+// accessString returns the value of a request field as a string, whatever its kind,
+// since fields may be typed (ll.HTTPMiddleware) or boxed (Fields, middleware rewrites).
+func accessString(f lx.Field) string {
+	switch v := f.Interface().(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// accessInt returns the value of an integer request field, such as the status or a
+// duration in nanoseconds, whatever its kind. Other values read as zero.
+func accessInt(f lx.Field) int64 {
+	switch v := f.Interface().(type) {
+	case int:
+		return int64(v)
+	case int32:
+		return int64(v)
+	case int64:
+		return v
+	case uint:
+		return int64(v)
+	case uint32:
+		return int64(v)
+	case uint64:
+		return int64(v)
+	case float64:
+		return int64(v)
+	case time.Duration:
+		return int64(v)
+	case string:
+		n, _ := strconv.ParseInt(v, 10, 64)
+		return n
+	default:
+		return 0
+	}
+}
+
+// orDash returns s, or "-" if s is empty, as Apache logs missing values.
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// writeAccessEscaped writes a quoted access log value, escaping quotes and backslashes
+// with a backslash and control bytes as \xHH, as Apache does, so a client cannot break
+// out of the quotes or start a new line.
+func writeAccessEscaped(buf *bytes.Buffer, s string) {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '"' || c == '\\':
+			buf.WriteByte('\\')
+			buf.WriteByte(c)
+		case c < 0x20 || c == 0x7f:
+			buf.WriteString(`\x`)
+			buf.WriteByte(hexDigits[c>>4])
+			buf.WriteByte(hexDigits[c&0xf])
+		default:
+			buf.WriteByte(c)
+		}
+	}
+}
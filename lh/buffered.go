@@ -6,6 +6,7 @@ import (
 	"os"
 	"runtime"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/olekukonko/ll/lx"
@@ -87,6 +88,7 @@ type Buffered[H lx.Handler] struct {
 	shutdown     chan struct{}
 	shutdownOnce sync.Once
 	wg           sync.WaitGroup
+	dropped      atomic.Uint64 // Entries rejected because the buffer was full
 }
 
 // NewBuffered creates a new buffered handler that wraps another handler.
@@ -147,28 +149,7 @@ func NewBuffered[H lx.Handler](handler H, opts ...BufferingOpt) *Buffered[H] {
 // cloneEntry creates a deep copy of an entry for safe asynchronous processing.
 // The original entry belongs to the logger's pool and is reused immediately after Handle() returns.
 func (b *Buffered[H]) cloneEntry(e *lx.Entry) *lx.Entry {
-	entryCopy := &lx.Entry{
-		Timestamp: e.Timestamp,
-		Level:     e.Level,
-		Message:   e.Message,
-		Namespace: e.Namespace,
-		Style:     e.Style,
-		Class:     e.Class,
-		Error:     e.Error,
-		Id:        e.Id,
-	}
-
-	if len(e.Fields) > 0 {
-		entryCopy.Fields = make(lx.Fields, len(e.Fields))
-		copy(entryCopy.Fields, e.Fields)
-	}
-
-	if len(e.Stack) > 0 {
-		entryCopy.Stack = make([]byte, len(e.Stack))
-		copy(entryCopy.Stack, e.Stack)
-	}
-
-	return entryCopy
+	return cloneEntry(e)
 }
 
 // Handle implements the lx.Handler interface.
@@ -179,6 +160,7 @@ func (b *Buffered[H]) Handle(e *lx.Entry) error {
 	case b.entries <- entryCopy:
 		return nil
 	default:
+		b.dropped.Add(1)
 		if b.config.OnOverflow != nil {
 			b.config.OnOverflow(len(b.entries))
 		}
@@ -186,6 +168,11 @@ func (b *Buffered[H]) Handle(e *lx.Entry) error {
 	}
 }
 
+// Dropped returns the number of entries rejected because the buffer was full.
+func (b *Buffered[H]) Dropped() uint64 {
+	return b.dropped.Load()
+}
+
 // Flush triggers an immediate flush of buffered entries.
 // If a flush is already pending, it waits briefly and may exit without flushing.
 // Thread-safe via non-blocking channel operations.
@@ -391,6 +391,9 @@ func (h *ColorizedHandler) handleRegularOutput(e *lx.Entry) error {
 	h.formatLevel(buf, e)
 	buf.WriteString(e.Message)
 	h.formatFields(buf, e)
+	if e.Error != nil {
+		h.formatError(buf, e.Error)
+	}
 	if len(e.Stack) > 0 {
 		h.formatStack(buf, e.Stack)
 	}
@@ -682,28 +685,65 @@ func (h *ColorizedHandler) formatFields(b *bytes.Buffer, e *lx.Entry) {
 	}
 	b.WriteString(lx.Space)
 	b.WriteString(lx.LeftBracket)
-	for i, pair := range e.Fields {
-		if i > 0 {
+	n := 0
+	flattenFields(e.Fields, "", func(key string, pair lx.Field) {
+		if n > 0 {
 			b.WriteString(lx.Space)
 		}
+		n++
 		if h.colorFields {
 			// Color the key
 			b.WriteString(h.palette.Key)
-			b.WriteString(pair.Key)
+			b.WriteString(key)
 			b.WriteString(h.palette.Reset)
 			b.WriteString("=")
 			// Format value with type-based coloring
-			h.formatFieldValue(b, pair.Value)
+			h.formatFieldPair(b, pair)
 		} else {
 			// No field coloring - just write plain text
-			b.WriteString(pair.Key)
+			b.WriteString(key)
 			b.WriteString("=")
-			writeFieldValue(b, pair.Value)
+			writeField(b, pair)
 		}
-	}
+	})
 	b.WriteString(lx.RightBracket)
 }
 
+// formatFieldPair formats a field with type-based ANSI color codes. Fields built with
+// the typed constructors are written from their unboxed payload, without allocating.
+func (h *ColorizedHandler) formatFieldPair(b *bytes.Buffer, pair lx.Field) {
+	var color string
+	switch pair.Kind {
+	case lx.KindString:
+		b.WriteString(h.palette.String)
+		b.WriteString(`"`)
+		b.WriteString(pair.StringValue())
+		b.WriteString(`"`)
+		b.WriteString(h.palette.Reset)
+		return
+	case lx.KindInt64, lx.KindUint64, lx.KindFloat64:
+		color = h.palette.Number
+	case lx.KindBool:
+		color = h.palette.Bool
+	case lx.KindDuration:
+		b.WriteString(h.palette.Time)
+		h.formatDuration(b, pair.DurationValue())
+		b.WriteString(h.palette.Reset)
+		return
+	case lx.KindTime:
+		b.WriteString(h.palette.Time)
+		writeTime(b, pair.TimeValue(), "2006-01-02 15:04:05")
+		b.WriteString(h.palette.Reset)
+		return
+	default: // KindAny, KindError
+		h.formatFieldValue(b, pair.Value)
+		return
+	}
+	b.WriteString(color)
+	writeField(b, pair)
+	b.WriteString(h.palette.Reset)
+}
+
 // formatFieldValue formats a field value with type-based ANSI color codes.
 func (h *ColorizedHandler) formatFieldValue(b *bytes.Buffer, value interface{}) {
 	// If field coloring is disabled, just write the value
@@ -711,7 +751,7 @@ func (h *ColorizedHandler) formatFieldValue(b *bytes.Buffer, value interface{})
 		writeFieldValue(b, value)
 		return
 	}
-	switch v := value.(type) {
+	switch v := lx.Resolve(value).(type) {
 	case time.Time:
 		b.WriteString(h.palette.Time)
 		b.WriteString(v.Format("2006-01-02 15:04:05"))
@@ -840,6 +880,61 @@ func (h *ColorizedHandler) formatStack(b *bytes.Buffer, stack []byte) {
 	b.WriteString("  └\n")
 }
 
+// formatError formats an error with its cause chain, concrete types and any stack
+// traces the errors carry, using the same layout as formatStack.
+func (h *ColorizedHandler) formatError(b *bytes.Buffer, err error) {
+	b.WriteString("\n")
+	b.WriteString(h.palette.Header)
+	b.WriteString("[error]")
+	b.WriteString(h.palette.Reset)
+	b.WriteString("\n")
+	h.formatErrorInfo(b, lx.DescribeError(err), 0, true)
+	b.WriteString("  └\n")
+}
+
+// formatErrorInfo writes one error of the chain and then its causes. A single cause
+// continues the chain at the same depth; several causes (errors.Join) are indented.
+func (h *ColorizedHandler) formatErrorInfo(b *bytes.Buffer, info *lx.ErrorInfo, depth int, first bool) {
+	indent := strings.Repeat("│   ", depth)
+	b.WriteString("  ")
+	b.WriteString(indent)
+	if first {
+		b.WriteString("┌─ ")
+	} else {
+		b.WriteString("├─ ")
+	}
+	b.WriteString(h.palette.Error)
+	b.WriteString(info.Message)
+	b.WriteString(h.palette.Reset)
+	b.WriteString(" <")
+	b.WriteString(h.palette.Func)
+	b.WriteString(info.Type)
+	b.WriteString(h.palette.Reset)
+	b.WriteString(">\n")
+	for _, frame := range info.Stack {
+		b.WriteString("  ")
+		b.WriteString(indent)
+		b.WriteString("│   at ")
+		b.WriteString(h.palette.Func)
+		b.WriteString(frame.Function)
+		b.WriteString(h.palette.Reset)
+		b.WriteString(" ")
+		b.WriteString(h.palette.Path)
+		b.WriteString(frame.File)
+		b.WriteString(":")
+		b.WriteString(strconv.Itoa(frame.Line))
+		b.WriteString(h.palette.Reset)
+		b.WriteString("\n")
+	}
+	if len(info.Causes) == 1 {
+		h.formatErrorInfo(b, info.Causes[0], depth, false)
+		return
+	}
+	for _, cause := range info.Causes {
+		h.formatErrorInfo(b, cause, depth+1, false)
+	}
+}
+
 // handleDumpOutput formats hex dump output with ANSI color codes.
 func (h *ColorizedHandler) handleDumpOutput(e *lx.Entry) error {
 	buf := colorBufPool.Get().(*bytes.Buffer)
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/cespare/xxhash/v2"
@@ -24,6 +25,8 @@ type Dedup struct {
 	cleanupEvery time.Duration
 	keyFn        lx.Deduper
 	maxKeys      int
+	summary      bool
+	dropped      atomic.Uint64          // Duplicates suppressed
 	shards       [shardCount]dedupShard // value array; take &shards[i] when locking
 	done         chan struct{}
 	wg           sync.WaitGroup
@@ -31,8 +34,17 @@ type Dedup struct {
 }
 
 type dedupShard struct {
-	mu   sync.Mutex
-	seen map[uint64]int64 // key -> expiry unix-nano timestamp
+	mu      sync.Mutex
+	seen    map[uint64]int64        // key -> expiry unix-nano timestamp
+	repeats map[uint64]*dedupRepeat // key -> suppressed repeats, only with summaries
+}
+
+// dedupRepeat tracks the duplicates suppressed for a key within its TTL window.
+type dedupRepeat struct {
+	entry *lx.Entry // Copy of the first suppressed duplicate
+	first int64     // When the forwarded original was seen, in unix nanoseconds
+	last  int64     // When the latest duplicate was seen, in unix nanoseconds
+	count int       // Duplicates suppressed
 }
 
 // DedupOpt configures a Dedup handler.
@@ -69,6 +81,23 @@ func WithDedupMaxKeys(max int) DedupOpt {
 	}
 }
 
+// WithDedupSummary makes the handler report suppressed duplicates instead of dropping
+// them silently. When a key that had repeats expires, or when the handler is closed,
+// one follow-up entry is forwarded with the original level, message, namespace and
+// fields plus repeat_count (duplicates suppressed, excluding the forwarded original),
+// first_seen and last_seen. Expired keys are found by the cleanup pass, which runs at
+// least once per TTL when summaries are enabled.
+//
+// Summaries are forwarded from the cleanup goroutine and from Close, outside of any
+// Handle call, so the Dedup must be used as a handler (NewDedup or Pipe with
+// PipeDedup). Through ll.MiddleWrap, which only passes on the entry of each call,
+// the summaries are rejected.
+func WithDedupSummary() DedupOpt {
+	return func(d *Dedup) {
+		d.summary = true
+	}
+}
+
 // WithDedupIgnore specifies fields to ignore in the default key function.
 func WithDedupIgnore(fields ...string) DedupOpt {
 	return func(d *Dedup) {
@@ -102,6 +131,14 @@ func NewDedup(next lx.Handler, ttl time.Duration, opts ...DedupOpt) *Dedup {
 	for _, opt := range opts {
 		opt(d)
 	}
+	if d.summary {
+		for i := 0; i < len(d.shards); i++ {
+			d.shards[i].repeats = make(map[uint64]*dedupRepeat)
+		}
+		if d.ttl < d.cleanupEvery {
+			d.cleanupEvery = d.ttl
+		}
+	}
 	d.wg.Add(1)
 	go d.cleanupLoop()
 	return d
@@ -123,36 +160,97 @@ func (d *Dedup) Handle(e *lx.Entry) error {
 	shard.mu.Lock()
 	exp, ok := shard.seen[key]
 	if ok && now < exp {
+		if d.summary {
+			d.recordRepeatLocked(shard, key, e, exp, now)
+		}
 		shard.mu.Unlock()
+		d.dropped.Add(1)
 		return nil // duplicate within TTL — suppress
 	}
 
+	// The key's previous window has expired; report its repeats before the new original.
+	var summaries []*lx.Entry
+	if r, ok := shard.repeats[key]; ok {
+		delete(shard.repeats, key)
+		summaries = append(summaries, r.summary())
+	}
+
 	// Opportunistic per-shard cleanup when the shard is getting full.
 	if d.maxKeys > 0 {
 		limitPerShard := d.maxKeys / shardCount
 		if limitPerShard > 0 && len(shard.seen) >= limitPerShard {
-			d.cleanupShardLocked(shard, now)
+			summaries = d.cleanupShardLocked(shard, now, summaries)
 		}
 	}
 
 	shard.seen[key] = now + d.ttl.Nanoseconds()
 	shard.mu.Unlock()
 
+	d.emit(summaries)
 	return d.next.Handle(e)
 }
 
+// Dropped returns the number of duplicates suppressed.
+func (d *Dedup) Dropped() uint64 {
+	return d.dropped.Load()
+}
+
+// recordRepeatLocked counts a suppressed duplicate of key whose window expires at exp
+// (caller must hold lock).
+func (d *Dedup) recordRepeatLocked(shard *dedupShard, key uint64, e *lx.Entry, exp, now int64) {
+	r, ok := shard.repeats[key]
+	if !ok {
+		// The entry belongs to the logger's pool, so keep a copy.
+		r = &dedupRepeat{entry: cloneEntry(e), first: exp - d.ttl.Nanoseconds()}
+		shard.repeats[key] = r
+	}
+	r.last = now
+	r.count++
+}
+
+// summary builds the follow-up entry reporting the suppressed repeats.
+func (r *dedupRepeat) summary() *lx.Entry {
+	e := r.entry
+	e.Timestamp = time.Now()
+	e.Fields = append(e.Fields,
+		lx.Int("repeat_count", r.count),
+		lx.Time("first_seen", time.Unix(0, r.first)),
+		lx.Time("last_seen", time.Unix(0, r.last)),
+	)
+	return e
+}
+
+// emit forwards summaries to the next handler, possibly from the cleanup goroutine.
+func (d *Dedup) emit(summaries []*lx.Entry) {
+	for _, e := range summaries {
+		d.next.Handle(e)
+	}
+}
+
 // getShardIndex returns the shard index for a given key.
 // Uses bitwise AND since shardCount is a power of 2.
 func (d *Dedup) getShardIndex(key uint64) int {
 	return int(key & (shardCount - 1))
 }
 
-// Close stops the cleanup goroutine and closes the underlying handler.
+// Close stops the cleanup goroutine, forwards pending summaries and closes the
+// underlying handler.
 func (d *Dedup) Close() error {
 	var err error
 	d.once.Do(func() {
 		close(d.done)
 		d.wg.Wait()
+		var summaries []*lx.Entry
+		for i := 0; i < len(d.shards); i++ {
+			shard := &d.shards[i]
+			shard.mu.Lock()
+			for k, r := range shard.repeats {
+				delete(shard.repeats, k)
+				summaries = append(summaries, r.summary())
+			}
+			shard.mu.Unlock()
+		}
+		d.emit(summaries)
 		if c, ok := d.next.(interface{ Close() error }); ok {
 			err = c.Close()
 		}
@@ -172,8 +270,9 @@ func (d *Dedup) cleanupLoop() {
 			for i := 0; i < len(d.shards); i++ {
 				shard := &d.shards[i]
 				shard.mu.Lock()
-				d.cleanupShardLocked(shard, now)
+				summaries := d.cleanupShardLocked(shard, now, nil)
 				shard.mu.Unlock()
+				d.emit(summaries)
 			}
 		case <-d.done:
 			return
@@ -181,13 +280,19 @@ func (d *Dedup) cleanupLoop() {
 	}
 }
 
-// cleanupShardLocked removes expired keys from a shard (caller must hold lock).
-func (d *Dedup) cleanupShardLocked(shard *dedupShard, now int64) {
+// cleanupShardLocked removes expired keys from a shard, appending the summaries of
+// those that had repeats to summaries (caller must hold lock).
+func (d *Dedup) cleanupShardLocked(shard *dedupShard, now int64, summaries []*lx.Entry) []*lx.Entry {
 	for k, exp := range shard.seen {
 		if now > exp {
 			delete(shard.seen, k)
+			if r, ok := shard.repeats[k]; ok {
+				delete(shard.repeats, k)
+				summaries = append(summaries, r.summary())
+			}
 		}
 	}
+	return summaries
 }
 
 // defaultDedup implements the default deduplication key calculation.
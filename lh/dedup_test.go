@@ -136,3 +136,60 @@ func TestDedup_SuppressesWithFields(t *testing.T) {
 
 	d.Close()
 }
+
+// TestDedup_Summary tests that repeats are reported once their TTL window expires
+func TestDedup_Summary(t *testing.T) {
+	mem := NewMemoryHandler()
+	ttl := 30 * time.Millisecond
+	d := NewDedup(mem, ttl, WithDedupSummary())
+	defer d.Close()
+
+	for i := 0; i < 5; i++ {
+		d.Handle(&lx.Entry{Level: lx.LevelError, Message: "disk full", Fields: lx.Fields{lx.String("disk", "sda")}})
+	}
+	d.Handle(&lx.Entry{Level: lx.LevelInfo, Message: "once"})
+	if got := len(mem.Entries()); got != 2 {
+		t.Fatalf("expected 2 forwarded entries, got %d", got)
+	}
+
+	// The cleanup pass runs at least once per TTL when summaries are enabled.
+	time.Sleep(3 * ttl)
+	entries := mem.Entries()
+	if len(entries) != 3 {
+		t.Fatalf("expected one summary, got %d entries", len(entries))
+	}
+	summary := entries[2]
+	if summary.Message != "disk full" || summary.Level != lx.LevelError {
+		t.Errorf("expected summary to keep the original entry, got %+v", summary)
+	}
+	if v, _ := summary.Fields.Get("disk"); v != "sda" {
+		t.Errorf("expected original fields, got %v", summary.Fields)
+	}
+	if v, _ := summary.Fields.Get("repeat_count"); v != int64(4) {
+		t.Errorf("expected repeat_count 4, got %v", v)
+	}
+	first, _ := summary.Fields.Get("first_seen")
+	last, _ := summary.Fields.Get("last_seen")
+	if first == nil || last == nil || last.(time.Time).Before(first.(time.Time)) {
+		t.Errorf("expected first_seen <= last_seen, got %v and %v", first, last)
+	}
+}
+
+// TestDedup_SummaryOnClose tests that pending repeats are reported on Close
+func TestDedup_SummaryOnClose(t *testing.T) {
+	mem := NewMemoryHandler()
+	d := NewDedup(mem, time.Hour, WithDedupSummary())
+
+	for i := 0; i < 3; i++ {
+		d.Handle(&lx.Entry{Level: lx.LevelWarn, Message: "retrying"})
+	}
+	d.Close()
+
+	entries := mem.Entries()
+	if len(entries) != 2 {
+		t.Fatalf("expected original and summary, got %d entries", len(entries))
+	}
+	if v, _ := entries[1].Fields.Get("repeat_count"); v != int64(2) {
+		t.Errorf("expected repeat_count 2, got %v", v)
+	}
+}
@@ -0,0 +1,180 @@
+package lh
+
+import (
+	"sync"
+
+	"github.com/olekukonko/ll/lx"
+)
+
+// BackfillKey is the field set to true on entries a FlightRecorder replays as context.
+const BackfillKey = "backfill"
+
+// FlightOpt configures a FlightRecorder.
+type FlightOpt func(*FlightRecorder)
+
+// WithFlightSize sets how many entries are kept per namespace (default 100).
+func WithFlightSize(n int) FlightOpt {
+	return func(f *FlightRecorder) {
+		if n > 0 {
+			f.size = n
+		}
+	}
+}
+
+// WithFlightTrigger sets the least severe level that replays the recorded context,
+// using the order Debug < Info < Warn < Error < Fatal (default Error).
+func WithFlightTrigger(level lx.LevelType) FlightOpt {
+	return func(f *FlightRecorder) {
+		if s := level.Severity(); s >= 0 {
+			f.trigger = s
+		}
+	}
+}
+
+// WithFlightMaxNamespaces bounds the number of namespaces with a buffer (default 1024).
+// When exceeded, the buffer of the namespace written to least recently is discarded.
+func WithFlightMaxNamespaces(n int) FlightOpt {
+	return func(f *FlightRecorder) {
+		if n > 0 {
+			f.maxNamespaces = n
+		}
+	}
+}
+
+// FlightRecorder keeps the entries a logger's level filter drops, such as Debug entries
+// on a logger at Info level, in a ring buffer per namespace. When an Error or Fatal
+// entry reaches its Wrap, the buffered entries of that namespace are written first,
+// oldest first, with their original timestamps and backfill=true, then the error. This
+// gives rare failures their debug context without logging at Debug all the time.
+// Thread-safe.
+//
+// Attach it with Logger.Recorder so it receives below-level entries, and wrap the
+// logger's handler with its Wrap method so it sees the entries that trigger a replay.
+// Example:
+//
+//	flight := lh.NewFlightRecorder(lh.WithFlightSize(200))
+//	logger := ll.New("app").Enable().Level(lx.LevelInfo).
+//	    Handler(lh.Pipe(lh.NewTextHandler(os.Stdout), flight.Wrap)).
+//	    Recorder(flight)
+//	logger.Debug("cache miss")   // Buffered, not written
+//	logger.Error("query failed") // Writes "cache miss [backfill=true]", then the error
+type FlightRecorder struct {
+	size          int
+	trigger       int
+	maxNamespaces int
+
+	mu    sync.Mutex
+	rings map[string]*flightRing
+	clock uint64 // Orders ring writes, for evicting the least recently written
+}
+
+// flightRing is a fixed-size ring of entries for one namespace.
+type flightRing struct {
+	entries []*lx.Entry
+	head    int    // Index of the oldest entry when full
+	touched uint64 // Clock value of the last write
+}
+
+// NewFlightRecorder creates a flight recorder.
+func NewFlightRecorder(opts ...FlightOpt) *FlightRecorder {
+	f := &FlightRecorder{
+		size:          100,
+		trigger:       lx.LevelError.Severity(),
+		maxNamespaces: 1024,
+		rings:         make(map[string]*flightRing),
+	}
+	for _, opt := range opts {
+		opt(f)
+	}
+	return f
+}
+
+// Handle records a copy of the entry in the ring buffer of its namespace. Nothing is
+// written; use it as a Logger.Recorder. Always returns nil.
+func (f *FlightRecorder) Handle(e *lx.Entry) error {
+	entryCopy := cloneEntry(e) // The logger reuses e once Handle returns
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	ring, ok := f.rings[e.Namespace]
+	if !ok {
+		if len(f.rings) >= f.maxNamespaces {
+			f.evictLocked()
+		}
+		ring = &flightRing{entries: make([]*lx.Entry, 0, f.size)}
+		f.rings[e.Namespace] = ring
+	}
+	f.clock++
+	ring.touched = f.clock
+	if len(ring.entries) < f.size {
+		ring.entries = append(ring.entries, entryCopy)
+		return nil
+	}
+	ring.entries[ring.head] = entryCopy
+	ring.head = (ring.head + 1) % f.size
+	return nil
+}
+
+// Wrap returns a handler forwarding entries to next. Entries at or above the trigger
+// level are preceded by the context recorded for their namespace, which is then cleared.
+// It has the lx.Wrap signature, for use with Pipe.
+func (f *FlightRecorder) Wrap(next lx.Handler) lx.Handler {
+	return &flightHandler{recorder: f, next: next}
+}
+
+// Reset discards all recorded entries.
+func (f *FlightRecorder) Reset() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.rings = make(map[string]*flightRing)
+}
+
+// take removes and returns the recorded entries of namespace, oldest first.
+func (f *FlightRecorder) take(namespace string) []*lx.Entry {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	ring, ok := f.rings[namespace]
+	if !ok {
+		return nil
+	}
+	delete(f.rings, namespace)
+	return append(ring.entries[ring.head:len(ring.entries):len(ring.entries)], ring.entries[:ring.head]...)
+}
+
+// evictLocked discards the ring written to least recently. Caller must hold the lock.
+func (f *FlightRecorder) evictLocked() {
+	var oldest string
+	var touched uint64
+	first := true
+	for ns, ring := range f.rings {
+		if first || ring.touched < touched {
+			oldest, touched, first = ns, ring.touched, false
+		}
+	}
+	delete(f.rings, oldest)
+}
+
+// flightHandler replays recorded context before triggering entries.
+type flightHandler struct {
+	recorder *FlightRecorder
+	next     lx.Handler
+}
+
+// Handle forwards the entry, preceded by the recorded context if it triggers a replay.
+func (h *flightHandler) Handle(e *lx.Entry) error {
+	if s := e.Level.Severity(); s >= 0 && s >= h.recorder.trigger {
+		for _, ctx := range h.recorder.take(e.Namespace) {
+			// Lazy values were left unresolved while recorded; compute them now
+			ctx.Fields = append(ctx.Fields.Resolve(), lx.Bool(BackfillKey, true))
+			h.next.Handle(ctx)
+		}
+	}
+	return h.next.Handle(e)
+}
+
+// Close closes the next handler if it implements a Close() error method.
+func (h *flightHandler) Close() error {
+	if c, ok := h.next.(interface{ Close() error }); ok {
+		return c.Close()
+	}
+	return nil
+}
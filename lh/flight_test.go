@@ -0,0 +1,57 @@
+package lh
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/olekukonko/ll/lx"
+)
+
+// TestFlight_Replay tests that recorded context precedes triggering entries, per namespace
+func TestFlight_Replay(t *testing.T) {
+	sink := NewMemoryHandler()
+	f := NewFlightRecorder(WithFlightSize(3))
+	h := f.Wrap(sink)
+
+	for i := 0; i < 5; i++ {
+		f.Handle(&lx.Entry{Level: lx.LevelDebug, Namespace: "db", Message: fmt.Sprintf("db %d", i)})
+	}
+	f.Handle(&lx.Entry{Level: lx.LevelDebug, Namespace: "http", Message: "http context"})
+	h.Handle(&lx.Entry{Level: lx.LevelInfo, Namespace: "db", Message: "info"})
+	h.Handle(&lx.Entry{Level: lx.LevelError, Namespace: "db", Message: "db failed"})
+	h.Handle(&lx.Entry{Level: lx.LevelError, Namespace: "db", Message: "db failed again"})
+
+	var got []string
+	for _, e := range sink.Entries() {
+		backfill, _ := e.Fields.Get(BackfillKey)
+		got = append(got, fmt.Sprintf("%s:%v", e.Message, backfill))
+	}
+	want := "[info:<nil> db 2:true db 3:true db 4:true db failed:<nil> db failed again:<nil>]"
+	if fmt.Sprint(got) != want {
+		t.Errorf("expected %s, got %v", want, got)
+	}
+	if ctx := f.take("http"); len(ctx) != 1 {
+		t.Errorf("expected other namespaces to keep their context, got %d entries", len(ctx))
+	}
+}
+
+// TestFlight_Trigger tests custom trigger levels and namespace eviction
+func TestFlight_Trigger(t *testing.T) {
+	sink := NewMemoryHandler()
+	f := NewFlightRecorder(WithFlightTrigger(lx.LevelWarn), WithFlightMaxNamespaces(2))
+	h := f.Wrap(sink)
+
+	f.Handle(&lx.Entry{Level: lx.LevelDebug, Namespace: "a", Message: "a"})
+	f.Handle(&lx.Entry{Level: lx.LevelDebug, Namespace: "b", Message: "b"})
+	f.Handle(&lx.Entry{Level: lx.LevelDebug, Namespace: "c", Message: "c"}) // Evicts "a"
+	h.Handle(&lx.Entry{Level: lx.LevelWarn, Namespace: "a", Message: "warn a"})
+	h.Handle(&lx.Entry{Level: lx.LevelWarn, Namespace: "b", Message: "warn b"})
+
+	var got []string
+	for _, e := range sink.Entries() {
+		got = append(got, e.Message)
+	}
+	if fmt.Sprint(got) != "[warn a b warn b]" {
+		t.Errorf("unexpected entries: %v", got)
+	}
+}
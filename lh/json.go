@@ -19,20 +19,28 @@ var jsonBufPool = sync.Pool{
 	},
 }
 
-// fieldsMapPool pools map[string]interface{} to reduce allocations
-var fieldsMapPool = sync.Pool{
-	New: func() any {
-		return make(map[string]interface{}, 8)
-	},
+// JSONOption configures a JSONHandler.
+type JSONOption func(*JSONHandler)
+
+// WithJSONPretty enables or disables indented output.
+func WithJSONPretty(pretty bool) JSONOption {
+	return func(h *JSONHandler) {
+		h.pretty = pretty
+	}
 }
 
-// jsonOutputPool pools JsonOutput structs to reduce allocations
-var jsonOutputPool = sync.Pool{
-	New: func() any {
-		return &JsonOutput{
-			Fields: make(map[string]interface{}, 8),
-		}
-	},
+// WithJSONTimeFormat sets the layout used for the "ts" key (default: time.RFC3339Nano).
+func WithJSONTimeFormat(format string) JSONOption {
+	return func(h *JSONHandler) {
+		h.timeFmt = format
+	}
+}
+
+// WithJSONDuplicates sets how fields sharing a key are written (default: JSONDuplicateLast).
+func WithJSONDuplicates(policy JSONDuplicates) JSONOption {
+	return func(h *JSONHandler) {
+		h.duplicates = policy
+	}
 }
 
 // JSONHandler is a handler that outputs log entries as JSON objects.
@@ -40,24 +48,27 @@ var jsonOutputPool = sync.Pool{
 // stack traces or dump segments, writing the result to the provided writer.
 // Thread-safe with a mutex to protect concurrent writes.
 type JSONHandler struct {
-	writer  io.Writer  // Destination for JSON output
-	timeFmt string     // Format for timestamp (default: RFC3339Nano)
-	pretty  bool       // Enable pretty printing with indentation if true
-	mu      sync.Mutex // Protects concurrent access to writer
+	writer     io.Writer      // Destination for JSON output
+	timeFmt    string         // Format for timestamp (default: RFC3339Nano)
+	pretty     bool           // Enable pretty printing with indentation if true
+	duplicates JSONDuplicates // Policy for fields sharing a key
+	mu         sync.Mutex     // Protects concurrent access to writer
 }
 
 // JsonOutput represents the JSON structure for a log entry.
 // It includes all relevant log data, such as timestamp, level, message, and optional
-// stack trace or dump segments, serialized as a JSON object.
+// stack trace or dump segments. JSONHandler writes this layout directly, keeping
+// fields in insertion order; the struct is useful for decoding its output.
 type JsonOutput struct {
-	Time      string                 `json:"ts"`     // Timestamp in specified format
-	Level     string                 `json:"lvl"`    // Log level (e.g., "INFO")
-	Class     string                 `json:"class"`  // Entry class (e.g., "Text", "Dump")
-	Msg       string                 `json:"msg"`    // Log message
-	Namespace string                 `json:"ns"`     // Namespace path
-	Stack     []byte                 `json:"stack"`  // Stack trace (if present)
-	Dump      []dumpSegment          `json:"dump"`   // Hex/ASCII dump segments (for ClassDump)
-	Fields    map[string]interface{} `json:"fields"` // Custom fields
+	Time      string                 `json:"ts"`              // Timestamp in specified format
+	Level     string                 `json:"lvl"`             // Log level (e.g., "INFO")
+	Class     string                 `json:"class"`           // Entry class (e.g., "Text", "Dump")
+	Msg       string                 `json:"msg"`             // Log message
+	Namespace string                 `json:"ns"`              // Namespace path
+	Stack     []byte                 `json:"stack"`           // Stack trace (if present)
+	Dump      []dumpSegment          `json:"dump"`            // Hex/ASCII dump segments (for ClassDump)
+	Fields    map[string]interface{} `json:"fields"`          // Custom fields
+	Error     *lx.ErrorInfo          `json:"error,omitempty"` // Entry error with its cause chain (if present)
 }
 
 // dumpSegment represents a single segment of a hex/ASCII dump.
@@ -75,8 +86,8 @@ type dumpSegment struct {
 //
 //	handler := NewJSONHandler(os.Stdout)
 //	logger := ll.New("app").Enable().Handler(handler)
-//	logger.Info("Test") // Output: {"ts":"...","lvl":"INFO","class":"Text","msg":"Test","ns":"app","stack":null,"dump":null,"fields":null}
-func NewJSONHandler(w io.Writer, opts ...func(*JSONHandler)) *JSONHandler {
+//	logger.Info("Test") // Output: {"ts":"...","lvl":"INFO","class":"Text","msg":"Test","ns":"app","stack":null,"dump":null,"fields":{}}
+func NewJSONHandler(w io.Writer, opts ...JSONOption) *JSONHandler {
 	h := &JSONHandler{
 		writer:  w,                // Set output writer
 		timeFmt: time.RFC3339Nano, // Default timestamp format
@@ -114,69 +125,14 @@ func (h *JSONHandler) Output(w io.Writer) {
 }
 
 // handleRegular handles standard log entries (non-dump).
-// It converts the entry to a JsonOutput struct and encodes it as JSON,
-// applying pretty printing if enabled. Logs encoding errors to stderr for debugging.
-// Returns an error if encoding or writing fails.
+// It streams the entry as JSON into a pooled buffer, applying pretty printing if
+// enabled, and writes the result in a single call.
+// Returns an error if writing fails.
 // Example (internal usage):
 //
 //	h.handleRegular(&lx.Entry{Message: "test", Level: lx.LevelInfo}) // Writes JSON object
 func (h *JSONHandler) handleRegular(e *lx.Entry) error {
-	// Get fieldsMap from pool to avoid allocation
-	fieldsMap := fieldsMapPool.Get().(map[string]interface{})
-	// Clear any existing keys from previous use
-	for k := range fieldsMap {
-		delete(fieldsMap, k)
-	}
-	// Convert ordered fields to map for JSON output
-	for _, pair := range e.Fields {
-		fieldsMap[pair.Key] = pair.Value
-	}
-
-	// Get JsonOutput from pool
-	entry := jsonOutputPool.Get().(*JsonOutput)
-	entry.Time = e.Timestamp.Format(h.timeFmt)
-	entry.Level = e.Level.String()
-	entry.Class = e.Class.String()
-	entry.Msg = e.Message
-	entry.Namespace = e.Namespace
-	entry.Dump = nil
-	entry.Fields = fieldsMap
-	entry.Stack = e.Stack
-
-	// Acquire buffer from pool to avoid allocation and reduce syscalls
-	buf := jsonBufPool.Get().(*bytes.Buffer)
-	buf.Reset()
-	defer func() {
-		// Return all pooled objects
-		jsonBufPool.Put(buf)
-		// Reset and return fieldsMap to pool
-		for k := range entry.Fields {
-			delete(entry.Fields, k)
-		}
-		fieldsMapPool.Put(entry.Fields)
-		// Reset and return JsonOutput to pool
-		entry.Fields = nil
-		entry.Stack = nil
-		entry.Dump = nil
-		jsonOutputPool.Put(entry)
-	}()
-
-	// Create JSON encoder writing to buffer (uses go-json for 2-5x speedup)
-	enc := json.NewEncoder(buf)
-	if h.pretty {
-		// Enable indentation for pretty printing
-		enc.SetIndent("", "  ")
-	}
-	// Encode JSON to buffer
-	err := enc.Encode(entry)
-	if err != nil {
-		// Log encoding error for debugging
-		fmt.Fprintf(os.Stderr, "JSON encode error: %v\n", err)
-		return err
-	}
-	// Write buffer to underlying writer in one go
-	_, err = h.writer.Write(buf.Bytes())
-	return err
+	return h.write(e, e.Message, nil)
 }
 
 // handleDump processes ClassDump entries, converting hex dump output to JSON segments.
@@ -201,63 +157,45 @@ func (h *JSONHandler) handleDump(e *lx.Entry) error {
 		// Parse position
 		var offset int
 		fmt.Sscanf(parts[0], "pos %d", &offset)
-		// Parse hex and ASCII
+		// Parse hex and ASCII; the ASCII column may be missing from truncated lines
 		hexAscii := strings.SplitN(parts[1], "'", 2)
 		hexStr := strings.Fields(strings.TrimSpace(hexAscii[0]))
+		var ascii string
+		if len(hexAscii) == 2 {
+			ascii = strings.Trim(hexAscii[1], "'")
+		}
 		// Create dump segment
 		segments = append(segments, dumpSegment{
-			Offset: offset,                         // Set byte offset
-			Hex:    hexStr,                         // Set hex values
-			ASCII:  strings.Trim(hexAscii[1], "'"), // Set ASCII representation
+			Offset: offset, // Set byte offset
+			Hex:    hexStr, // Set hex values
+			ASCII:  ascii,  // Set ASCII representation
 		})
 	}
 
-	// Get fieldsMap from pool
-	fieldsMap := fieldsMapPool.Get().(map[string]interface{})
-	for k := range fieldsMap {
-		delete(fieldsMap, k)
-	}
-	for _, pair := range e.Fields {
-		fieldsMap[pair.Key] = pair.Value
-	}
-
-	// Get JsonOutput from pool
-	entry := jsonOutputPool.Get().(*JsonOutput)
-	entry.Time = e.Timestamp.Format(h.timeFmt)
-	entry.Level = e.Level.String()
-	entry.Class = e.Class.String()
-	entry.Msg = "dumping segments"
-	entry.Namespace = e.Namespace
-	entry.Dump = segments
-	entry.Fields = fieldsMap
-	entry.Stack = e.Stack
+	return h.write(e, "dumping segments", segments)
+}
 
-	// Acquire buffer from pool
+// write encodes the entry into a pooled buffer and writes it to the underlying writer.
+func (h *JSONHandler) write(e *lx.Entry, msg string, dump []dumpSegment) error {
+	// Acquire buffer from pool to avoid allocation and reduce syscalls
 	buf := jsonBufPool.Get().(*bytes.Buffer)
 	buf.Reset()
-	defer func() {
-		jsonBufPool.Put(buf)
-		for k := range entry.Fields {
-			delete(entry.Fields, k)
-		}
-		fieldsMapPool.Put(entry.Fields)
-		entry.Fields = nil
-		entry.Stack = nil
-		entry.Dump = nil
-		jsonOutputPool.Put(entry)
-	}()
+	defer jsonBufPool.Put(buf)
 
-	// Encode JSON output with dump segments to buffer
-	enc := json.NewEncoder(buf)
+	h.encodeEntry(buf, e, msg, dump)
 	if h.pretty {
-		enc.SetIndent("", "  ")
-	}
-	err := enc.Encode(entry)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "JSON dump encode error: %v\n", err)
-		return err
+		out := jsonBufPool.Get().(*bytes.Buffer)
+		out.Reset()
+		defer jsonBufPool.Put(out)
+		if err := json.Indent(out, buf.Bytes(), "", "  "); err != nil {
+			// Log encoding error for debugging
+			fmt.Fprintf(os.Stderr, "JSON encode error: %v\n", err)
+			return err
+		}
+		buf = out
 	}
-	// Write buffer to underlying writer
-	_, err = h.writer.Write(buf.Bytes())
+	buf.WriteByte('\n')
+	// Write buffer to underlying writer in one go
+	_, err := h.writer.Write(buf.Bytes())
 	return err
 }
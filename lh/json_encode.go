@@ -0,0 +1,335 @@
+package lh
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	"github.com/goccy/go-json"
+	"github.com/olekukonko/ll/lx"
+)
+
+// JSONDuplicates controls how JSONHandler writes fields that share a key.
+type JSONDuplicates int
+
+const (
+	JSONDuplicateLast  JSONDuplicates = iota // Keep only the last field for a key (default)
+	JSONDuplicateFirst                       // Keep only the first field for a key
+	JSONDuplicateAll                         // Write every field, repeating keys as they occur
+)
+
+const hexDigits = "0123456789abcdef"
+
+// encodeEntry streams an entry as a compact JSON object into buf, writing
+// fields in insertion order. Only values without a native encoding (structs,
+// maps, slices, ...) are marshaled with go-json.
+func (h *JSONHandler) encodeEntry(buf *bytes.Buffer, e *lx.Entry, msg string, dump []dumpSegment) {
+	buf.WriteString(`{"ts":`)
+	writeJSONTime(buf, e.Timestamp, h.timeFmt)
+	buf.WriteString(`,"lvl":`)
+	writeJSONString(buf, e.Level.String())
+	buf.WriteString(`,"class":`)
+	writeJSONString(buf, e.Class.String())
+	buf.WriteString(`,"msg":`)
+	writeJSONString(buf, msg)
+	buf.WriteString(`,"ns":`)
+	writeJSONString(buf, e.Namespace)
+	buf.WriteString(`,"stack":`)
+	writeJSONBytes(buf, e.Stack)
+	buf.WriteString(`,"dump":`)
+	if dump == nil {
+		buf.WriteString("null")
+	} else {
+		writeJSONValue(buf, dump)
+	}
+	buf.WriteString(`,"fields":`)
+	writeJSONFields(buf, e.Fields, h.duplicates)
+	if e.Error != nil {
+		buf.WriteString(`,"error":`)
+		writeJSONFallback(buf, lx.DescribeError(e.Error))
+	}
+	buf.WriteByte('}')
+}
+
+// writeJSONFields writes fields as a JSON object in order, writing groups as nested
+// objects and applying the duplicate-key policy at each level.
+func writeJSONFields(buf *bytes.Buffer, fields lx.Fields, policy JSONDuplicates) {
+	buf.WriteByte('{')
+	skip := duplicateSkips(fields, policy)
+	first := true
+	for i, f := range fields {
+		if skip != nil && skip[i] {
+			continue
+		}
+		if !first {
+			buf.WriteByte(',')
+		}
+		first = false
+		writeJSONString(buf, f.Key)
+		buf.WriteByte(':')
+		if g, ok := f.GroupValue(); ok {
+			writeJSONFields(buf, g, policy)
+			continue
+		}
+		writeJSONField(buf, f)
+	}
+	buf.WriteByte('}')
+}
+
+// duplicateSkips returns which fields the duplicate-key policy drops, or nil if none.
+// A bitmap of key hashes rules out repeated keys in one pass without allocating; the
+// keys are only indexed in a map when two of them hash to the same bit.
+func duplicateSkips(fields lx.Fields, policy JSONDuplicates) []bool {
+	if policy == JSONDuplicateAll || len(fields) < 2 {
+		return nil
+	}
+	var seen [4]uint64
+	collision := false
+	for _, f := range fields {
+		h := keyHash(f.Key) & 255
+		word, bit := h>>6, uint64(1)<<(h&63)
+		if seen[word]&bit != 0 {
+			collision = true
+			break
+		}
+		seen[word] |= bit
+	}
+	if !collision {
+		return nil
+	}
+
+	kept := make(map[string]int, len(fields)) // Key -> index of the field written for it
+	for i, f := range fields {
+		if _, ok := kept[f.Key]; ok && policy == JSONDuplicateFirst {
+			continue
+		}
+		kept[f.Key] = i
+	}
+	var skip []bool
+	for i, f := range fields {
+		if kept[f.Key] != i {
+			if skip == nil {
+				skip = make([]bool, len(fields))
+			}
+			skip[i] = true
+		}
+	}
+	return skip
+}
+
+// keyHash returns the FNV-1a hash of key.
+func keyHash(key string) uint64 {
+	h := uint64(14695981039346656037)
+	for i := 0; i < len(key); i++ {
+		h ^= uint64(key[i])
+		h *= 1099511628211
+	}
+	return h
+}
+
+// writeJSONField writes a field value, encoding typed fields from their unboxed payload.
+func writeJSONField(buf *bytes.Buffer, f lx.Field) {
+	switch f.Kind {
+	case lx.KindString:
+		writeJSONString(buf, f.StringValue())
+	case lx.KindInt64:
+		buf.Write(strconv.AppendInt(buf.AvailableBuffer(), f.Int64Value(), 10))
+	case lx.KindUint64:
+		buf.Write(strconv.AppendUint(buf.AvailableBuffer(), f.Uint64Value(), 10))
+	case lx.KindFloat64:
+		writeJSONFloat(buf, f.Float64Value(), 64)
+	case lx.KindBool:
+		buf.WriteString(strconv.FormatBool(f.BoolValue()))
+	case lx.KindDuration:
+		buf.Write(strconv.AppendInt(buf.AvailableBuffer(), int64(f.DurationValue()), 10))
+	case lx.KindTime:
+		writeJSONTime(buf, f.TimeValue(), time.RFC3339Nano)
+	case lx.KindError:
+		writeJSONValue(buf, f.ErrorValue())
+	default:
+		writeJSONValue(buf, f.Value)
+	}
+}
+
+// writeJSONValue writes a boxed value, using native encodings for common types and
+// falling back to go-json for everything else. Values go-json cannot encode are
+// written as their fmt representation so a single bad field never loses the entry.
+func writeJSONValue(buf *bytes.Buffer, v interface{}) {
+	switch val := v.(type) {
+	case nil:
+		buf.WriteString("null")
+	case string:
+		writeJSONString(buf, val)
+	case bool:
+		buf.WriteString(strconv.FormatBool(val))
+	case int:
+		buf.Write(strconv.AppendInt(buf.AvailableBuffer(), int64(val), 10))
+	case int8:
+		buf.Write(strconv.AppendInt(buf.AvailableBuffer(), int64(val), 10))
+	case int16:
+		buf.Write(strconv.AppendInt(buf.AvailableBuffer(), int64(val), 10))
+	case int32:
+		buf.Write(strconv.AppendInt(buf.AvailableBuffer(), int64(val), 10))
+	case int64:
+		buf.Write(strconv.AppendInt(buf.AvailableBuffer(), val, 10))
+	case uint:
+		buf.Write(strconv.AppendUint(buf.AvailableBuffer(), uint64(val), 10))
+	case uint8:
+		buf.Write(strconv.AppendUint(buf.AvailableBuffer(), uint64(val), 10))
+	case uint16:
+		buf.Write(strconv.AppendUint(buf.AvailableBuffer(), uint64(val), 10))
+	case uint32:
+		buf.Write(strconv.AppendUint(buf.AvailableBuffer(), uint64(val), 10))
+	case uint64:
+		buf.Write(strconv.AppendUint(buf.AvailableBuffer(), val, 10))
+	case float32:
+		writeJSONFloat(buf, float64(val), 32)
+	case float64:
+		writeJSONFloat(buf, val, 64)
+	case time.Duration:
+		buf.Write(strconv.AppendInt(buf.AvailableBuffer(), int64(val), 10))
+	case time.Time:
+		writeJSONTime(buf, val, time.RFC3339Nano)
+	case lx.LogValuer:
+		writeJSONValue(buf, lx.Resolve(val))
+	case lx.Fields:
+		writeJSONFields(buf, val, JSONDuplicateLast)
+	case json.Marshaler:
+		writeJSONFallback(buf, v)
+	case error:
+		writeJSONString(buf, val.Error())
+	default:
+		writeJSONFallback(buf, v)
+	}
+}
+
+// writeJSONFallback marshals a complex value with go-json.
+func writeJSONFallback(buf *bytes.Buffer, v interface{}) {
+	data, err := json.MarshalNoEscape(v)
+	if err != nil {
+		writeJSONString(buf, fmt.Sprintf("%+v", v))
+		return
+	}
+	buf.Write(data)
+}
+
+// writeJSONFloat writes a float the way encoding/json does. NaN and infinities,
+// which JSON cannot represent, are written as strings.
+func writeJSONFloat(buf *bytes.Buffer, f float64, bits int) {
+	switch {
+	case math.IsNaN(f):
+		buf.WriteString(`"NaN"`)
+		return
+	case math.IsInf(f, 1):
+		buf.WriteString(`"+Inf"`)
+		return
+	case math.IsInf(f, -1):
+		buf.WriteString(`"-Inf"`)
+		return
+	}
+	format := byte('f')
+	if abs := math.Abs(f); abs != 0 {
+		if bits == 64 && (abs < 1e-6 || abs >= 1e21) || bits == 32 && (float32(abs) < 1e-6 || float32(abs) >= 1e21) {
+			format = 'e'
+		}
+	}
+	b := strconv.AppendFloat(buf.AvailableBuffer(), f, format, -1, bits)
+	if format == 'e' {
+		// Clean up e-09 to e-9.
+		if n := len(b); n >= 4 && b[n-4] == 'e' && b[n-3] == '-' && b[n-2] == '0' {
+			b[n-2] = b[n-1]
+			b = b[:n-1]
+		}
+	}
+	buf.Write(b)
+}
+
+// writeJSONTime writes t formatted with layout as a JSON string without allocating
+// for layouts that need no escaping.
+func writeJSONTime(buf *bytes.Buffer, t time.Time, layout string) {
+	var scratch [64]byte
+	b := t.AppendFormat(scratch[:0], layout)
+	for _, c := range b {
+		if c < utf8.RuneSelf && c >= 0x20 && c != '"' && c != '\\' {
+			continue
+		}
+		writeJSONString(buf, string(b))
+		return
+	}
+	buf.WriteByte('"')
+	buf.Write(b)
+	buf.WriteByte('"')
+}
+
+// writeJSONBytes writes a byte slice as a base64 string, or null if it is nil.
+func writeJSONBytes(buf *bytes.Buffer, b []byte) {
+	if b == nil {
+		buf.WriteString("null")
+		return
+	}
+	n := base64.StdEncoding.EncodedLen(len(b))
+	buf.Grow(n + 2)
+	buf.WriteByte('"')
+	dst := buf.AvailableBuffer()[:n]
+	base64.StdEncoding.Encode(dst, b)
+	buf.Write(dst)
+	buf.WriteByte('"')
+}
+
+// writeJSONString writes s as a quoted JSON string. Invalid UTF-8 is replaced with
+// U+FFFD; HTML characters are left unescaped.
+func writeJSONString(buf *bytes.Buffer, s string) {
+	buf.WriteByte('"')
+	start := 0
+	for i := 0; i < len(s); {
+		if c := s[i]; c < utf8.RuneSelf {
+			if c >= 0x20 && c != '"' && c != '\\' {
+				i++
+				continue
+			}
+			buf.WriteString(s[start:i])
+			switch c {
+			case '"', '\\':
+				buf.WriteByte('\\')
+				buf.WriteByte(c)
+			case '\n':
+				buf.WriteString(`\n`)
+			case '\r':
+				buf.WriteString(`\r`)
+			case '\t':
+				buf.WriteString(`\t`)
+			default:
+				buf.WriteString(`\u00`)
+				buf.WriteByte(hexDigits[c>>4])
+				buf.WriteByte(hexDigits[c&0xF])
+			}
+			i++
+			start = i
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			buf.WriteString(s[start:i])
+			buf.WriteString(`\ufffd`)
+			i += size
+			start = i
+			continue
+		}
+		// U+2028 and U+2029 are valid JSON but break JavaScript string literals.
+		if r == '\u2028' || r == '\u2029' {
+			buf.WriteString(s[start:i])
+			buf.WriteString(`\u202`)
+			buf.WriteByte(hexDigits[r&0xF])
+			i += size
+			start = i
+			continue
+		}
+		i += size
+	}
+	buf.WriteString(s[start:])
+	buf.WriteByte('"')
+}
@@ -0,0 +1,231 @@
+package lh
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	gojson "github.com/goccy/go-json"
+	"github.com/olekukonko/ll/lx"
+)
+
+// TestJSONHandler_FieldOrder verifies that fields are written in insertion order.
+func TestJSONHandler_FieldOrder(t *testing.T) {
+	buf := &bytes.Buffer{}
+	h := NewJSONHandler(buf)
+	h.Handle(&lx.Entry{
+		Message: "ordered",
+		Fields:  lx.Fields{{Key: "z", Value: 1}, {Key: "a", Value: 2}, {Key: "m", Value: 3}},
+	})
+	if !strings.Contains(buf.String(), `"fields":{"z":1,"a":2,"m":3}`) {
+		t.Errorf("expected fields in insertion order, got %s", buf.String())
+	}
+}
+
+// TestJSONHandler_Duplicates covers each duplicate-key policy.
+func TestJSONHandler_Duplicates(t *testing.T) {
+	fields := lx.Fields{{Key: "k", Value: 1}, {Key: "x", Value: 0}, {Key: "k", Value: 2}}
+	tests := []struct {
+		policy JSONDuplicates
+		want   string
+	}{
+		{JSONDuplicateLast, `"fields":{"x":0,"k":2}`},
+		{JSONDuplicateFirst, `"fields":{"k":1,"x":0}`},
+		{JSONDuplicateAll, `"fields":{"k":1,"x":0,"k":2}`},
+	}
+	for _, tt := range tests {
+		buf := &bytes.Buffer{}
+		NewJSONHandler(buf, WithJSONDuplicates(tt.policy)).Handle(&lx.Entry{Fields: fields})
+		if !strings.Contains(buf.String(), tt.want) {
+			t.Errorf("policy %d: expected %s, got %s", tt.policy, tt.want, buf.String())
+		}
+	}
+}
+
+// TestJSONHandler_ManyFields verifies the duplicate policy when key hashes collide:
+// with more keys than bitmap bits, distinct keys must all be kept.
+func TestJSONHandler_ManyFields(t *testing.T) {
+	var fields lx.Fields
+	for i := 0; i < 300; i++ {
+		fields = append(fields, lx.Int(fmt.Sprintf("k%d", i), i))
+	}
+	fields = append(fields, lx.Int("k7", -1))
+
+	buf := &bytes.Buffer{}
+	NewJSONHandler(buf).Handle(&lx.Entry{Fields: fields})
+	var out struct {
+		Fields map[string]interface{} `json:"fields"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(out.Fields) != 300 || out.Fields["k7"] != float64(-1) || out.Fields["k299"] != float64(299) {
+		t.Errorf("unexpected fields: %d keys, k7=%v", len(out.Fields), out.Fields["k7"])
+	}
+	if n := strings.Count(buf.String(), `"k7":`); n != 1 {
+		t.Errorf("expected k7 once, got %d times", n)
+	}
+}
+
+// TestJSONHandler_Values verifies native and fallback encodings decode to the expected values.
+func TestJSONHandler_Values(t *testing.T) {
+	ts := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	buf := &bytes.Buffer{}
+	h := NewJSONHandler(buf)
+	err := h.Handle(&lx.Entry{
+		Timestamp: ts,
+		Message:   "quote \" backslash \\ newline \n tab \t ctrl \x01 bad \xff <html>",
+		Stack:     []byte("trace"),
+		Fields: lx.Fields{
+			lx.String("s", "v"),
+			lx.Int64("i", -5),
+			lx.Uint64("u", math.MaxUint64),
+			lx.Float64("f", 0.5),
+			lx.Float64("tiny", 1e-9),
+			lx.Float64("nan", math.NaN()),
+			lx.Bool("b", false),
+			lx.Duration("d", time.Second),
+			lx.Time("t", ts),
+			lx.Err("err", errors.New("boom")),
+			{Key: "boxed_err", Value: errors.New("boxed")},
+			{Key: "nil", Value: nil},
+			{Key: "slice", Value: []int{1, 2}},
+			{Key: "map", Value: map[string]int{"a": 1}},
+			{Key: "chan", Value: make(chan int)},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var out map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("invalid JSON %q: %v", buf.String(), err)
+	}
+	if want := "quote \" backslash \\ newline \n tab \t ctrl \x01 bad � <html>"; out["msg"] != want {
+		t.Errorf("expected msg %q, got %q", want, out["msg"])
+	}
+	if out["ts"] != ts.Format(time.RFC3339Nano) || out["stack"] != "dHJhY2U=" || out["dump"] != nil {
+		t.Errorf("unexpected header keys: %v", out)
+	}
+	f := out["fields"].(map[string]interface{})
+	checks := map[string]interface{}{
+		"s": "v", "i": float64(-5), "u": float64(math.MaxUint64), "f": 0.5, "tiny": 1e-9,
+		"nan": "NaN", "b": false, "d": float64(time.Second), "t": "2024-05-01T12:00:00Z",
+		"err": "boom", "boxed_err": "boxed", "nil": nil,
+	}
+	for k, want := range checks {
+		if f[k] != want {
+			t.Errorf("field %s: expected %v, got %v", k, want, f[k])
+		}
+	}
+	if s, ok := f["slice"].([]interface{}); !ok || len(s) != 2 {
+		t.Errorf("expected slice to be encoded by fallback, got %v", f["slice"])
+	}
+	if m, ok := f["map"].(map[string]interface{}); !ok || m["a"] != float64(1) {
+		t.Errorf("expected map to be encoded by fallback, got %v", f["map"])
+	}
+	if s, ok := f["chan"].(string); !ok || !strings.HasPrefix(s, "0x") {
+		t.Errorf("expected unencodable value as string, got %v", f["chan"])
+	}
+}
+
+// TestJSONHandler_Pretty verifies indented output and the dump layout.
+func TestJSONHandler_Pretty(t *testing.T) {
+	buf := &bytes.Buffer{}
+	h := NewJSONHandler(buf, WithJSONPretty(true))
+	h.Handle(&lx.Entry{Class: lx.ClassDump, Message: "pos 00 hex: 61 62 'ab'", Fields: lx.Fields{{Key: "k", Value: "v"}}})
+
+	if !strings.Contains(buf.String(), "\n  \"lvl\"") || !strings.HasSuffix(buf.String(), "}\n") {
+		t.Errorf("expected indented output ending in newline, got %q", buf.String())
+	}
+	var out JsonOutput
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(out.Dump) != 1 || out.Dump[0].ASCII != "ab" || out.Fields["k"] != "v" {
+		t.Errorf("unexpected dump output: %+v", out)
+	}
+}
+
+// TestJSONHandler_DumpWithoutASCII verifies that dump lines missing the ASCII column,
+// such as truncated lines, are parsed instead of panicking.
+func TestJSONHandler_DumpWithoutASCII(t *testing.T) {
+	buf := &bytes.Buffer{}
+	h := NewJSONHandler(buf)
+	if err := h.Handle(&lx.Entry{Class: lx.ClassDump, Message: "pos 00 hex: 61 62 'ab'\npos 02 hex: 63 64\n"}); err != nil {
+		t.Fatalf("Handle failed: %v", err)
+	}
+
+	var out JsonOutput
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(out.Dump) != 2 || out.Dump[1].Offset != 2 || strings.Join(out.Dump[1].Hex, " ") != "63 64" || out.Dump[1].ASCII != "" {
+		t.Errorf("unexpected dump output: %+v", out.Dump)
+	}
+}
+
+// legacyJSONEncode reproduces the previous map-based encoding for benchmark comparison.
+func legacyJSONEncode(w io.Writer, e *lx.Entry) error {
+	fields := make(map[string]interface{}, len(e.Fields))
+	for _, pair := range e.Fields {
+		fields[pair.Key] = pair.Interface()
+	}
+	var buf bytes.Buffer
+	err := gojson.NewEncoder(&buf).Encode(&JsonOutput{
+		Time:      e.Timestamp.Format(time.RFC3339Nano),
+		Level:     e.Level.String(),
+		Class:     e.Class.String(),
+		Msg:       e.Message,
+		Namespace: e.Namespace,
+		Stack:     e.Stack,
+		Fields:    fields,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(buf.Bytes())
+	return err
+}
+
+func benchJSONEntry() *lx.Entry {
+	return &lx.Entry{
+		Timestamp: time.Now(),
+		Level:     lx.LevelInfo,
+		Message:   "request served",
+		Namespace: "app/http",
+		Fields: lx.Fields{
+			{Key: "method", Value: "GET"},
+			{Key: "path", Value: "/api/users"},
+			{Key: "status", Value: 200},
+			{Key: "latency", Value: 1500 * time.Microsecond},
+			{Key: "ok", Value: true},
+		},
+	}
+}
+
+// BenchmarkJSONHandler_Streaming measures the streaming encoder.
+func BenchmarkJSONHandler_Streaming(b *testing.B) {
+	h := NewJSONHandler(io.Discard)
+	e := benchJSONEntry()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		h.Handle(e)
+	}
+}
+
+// BenchmarkJSONHandler_Map measures the previous map-based encoding.
+func BenchmarkJSONHandler_Map(b *testing.B) {
+	e := benchJSONEntry()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		legacyJSONEncode(io.Discard, e)
+	}
+}
@@ -4,6 +4,9 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
+
+	"github.com/olekukonko/ll/lx"
 )
 
 // rightPad pads a string with spaces on the right to reach the specified length.
@@ -65,6 +68,8 @@ func writeFieldValue(b stringWriter, v interface{}) {
 		}
 	case nil:
 		b.WriteString("nil")
+	case lx.LogValuer:
+		writeFieldValue(b, lx.Resolve(val))
 	case error:
 		b.WriteString(val.Error())
 	case fmt.Stringer:
@@ -73,3 +78,89 @@ func writeFieldValue(b stringWriter, v interface{}) {
 		fmt.Fprint(b, val)
 	}
 }
+
+// flattenFields calls fn for every field that is not a group, passing its key joined
+// to the keys of the enclosing groups with dots (e.g., "http.method").
+func flattenFields(fields lx.Fields, prefix string, fn func(key string, f lx.Field)) {
+	for _, f := range fields {
+		key := f.Key
+		if prefix != "" {
+			key = prefix + lx.Dot + f.Key
+		}
+		if g, ok := f.GroupValue(); ok {
+			flattenFields(g, key, fn)
+			continue
+		}
+		fn(key, f)
+	}
+}
+
+// timeStringLayout matches time.Time.String, so typed and boxed times render alike.
+const timeStringLayout = "2006-01-02 15:04:05.999999999 -0700 MST"
+
+// writeField writes a field's value to the builder, encoding typed fields
+// directly from their unboxed payload and falling back to writeFieldValue.
+func writeField(b stringWriter, f lx.Field) {
+	switch f.Kind {
+	case lx.KindString:
+		b.WriteString(f.StringValue())
+	case lx.KindInt64:
+		b.WriteString(strconv.FormatInt(f.Int64Value(), 10))
+	case lx.KindUint64:
+		b.WriteString(strconv.FormatUint(f.Uint64Value(), 10))
+	case lx.KindFloat64:
+		b.WriteString(strconv.FormatFloat(f.Float64Value(), 'g', -1, 64))
+	case lx.KindBool:
+		if f.BoolValue() {
+			b.WriteString("true")
+		} else {
+			b.WriteString("false")
+		}
+	case lx.KindDuration:
+		b.WriteString(f.DurationValue().String())
+	case lx.KindTime:
+		writeTime(b, f.TimeValue(), timeStringLayout)
+	case lx.KindError:
+		if err := f.ErrorValue(); err != nil {
+			b.WriteString(err.Error())
+		} else {
+			b.WriteString("nil")
+		}
+	default:
+		writeFieldValue(b, f.Value)
+	}
+}
+
+// writeTime appends t formatted with layout using a stack buffer to avoid allocating.
+func writeTime(b stringWriter, t time.Time, layout string) {
+	var scratch [64]byte
+	b.Write(t.AppendFormat(scratch[:0], layout))
+}
+
+// cloneEntry creates a deep copy of an entry for safe asynchronous processing.
+// Entries handed to a handler belong to the logger's pool and are reused as soon as
+// Handle returns, so any handler that keeps an entry beyond that point must copy it.
+func cloneEntry(e *lx.Entry) *lx.Entry {
+	entryCopy := &lx.Entry{
+		Timestamp: e.Timestamp,
+		Level:     e.Level,
+		Message:   e.Message,
+		Namespace: e.Namespace,
+		Style:     e.Style,
+		Class:     e.Class,
+		Error:     e.Error,
+		Id:        e.Id,
+	}
+
+	if len(e.Fields) > 0 {
+		entryCopy.Fields = make(lx.Fields, len(e.Fields))
+		copy(entryCopy.Fields, e.Fields)
+	}
+
+	if len(e.Stack) > 0 {
+		entryCopy.Stack = make([]byte, len(e.Stack))
+		copy(entryCopy.Stack, e.Stack)
+	}
+
+	return entryCopy
+}
@@ -0,0 +1,371 @@
+// Package lhtest checks lx.Handler implementations against the contract handlers are
+// expected to honour when plugged into an ll.Logger.
+//
+// A handler must:
+//   - accept entries of every lx.ClassType, including malformed ClassDump messages;
+//   - accept entries with nil or empty fields, messages, namespaces and timestamps;
+//   - accept very large messages and many fields;
+//   - be safe for concurrent use by several goroutines, accepting 1,600 entries from
+//     8 goroutines without error; handlers that apply backpressure, such as
+//     lh.Buffered, must be created with enough capacity;
+//   - not retain the *lx.Entry it is given, nor its Fields or Stack: the logger
+//     returns entries to a pool and reuses them as soon as Handle returns;
+//   - write to the new destination after lx.Outputter.Output, and honour the format
+//     passed to lx.Timestamper.Timestamped;
+//   - tolerate Close being called more than once.
+//
+// Example:
+//
+//	func TestConformance(t *testing.T) {
+//	    lhtest.RunConformance(t, func(w io.Writer) lx.Handler {
+//	        return myhandler.New(w)
+//	    })
+//	}
+package lhtest
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/olekukonko/ll/lx"
+)
+
+// Factory creates a new instance of the handler under test. Handlers that write to an
+// io.Writer must write to w, so the suite can inspect their output; others may ignore it.
+type Factory func(w io.Writer) lx.Handler
+
+// RunConformance runs the conformance checks against handlers created by factory, each
+// as a subtest. Run it with -race to detect data races in concurrent Handle calls.
+func RunConformance(t *testing.T, factory Factory) {
+	t.Helper()
+	t.Run("Classes", func(t *testing.T) { testClasses(t, factory) })
+	t.Run("EmptyValues", func(t *testing.T) { testEmptyValues(t, factory) })
+	t.Run("LargeEntries", func(t *testing.T) { testLargeEntries(t, factory) })
+	t.Run("Concurrent", func(t *testing.T) { testConcurrent(t, factory) })
+	t.Run("EntryReuse", func(t *testing.T) { testEntryReuse(t, factory) })
+	t.Run("Outputter", func(t *testing.T) { testOutputter(t, factory) })
+	t.Run("Timestamper", func(t *testing.T) { testTimestamper(t, factory) })
+	t.Run("Close", func(t *testing.T) { testClose(t, factory) })
+}
+
+// syncBuffer is a bytes.Buffer safe for concurrent writes, since handlers are only
+// thread-safe if their writer is.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// entry returns a representative entry of class.
+func entry(class lx.ClassType, msg string) *lx.Entry {
+	return &lx.Entry{
+		Timestamp: time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC),
+		Level:     lx.LevelInfo,
+		Message:   msg,
+		Namespace: "app/conformance",
+		Class:     class,
+		Fields:    lx.Fields{lx.String("key", "value"), lx.Int("n", 42)},
+	}
+}
+
+// handle calls h.Handle, converting a panic into a test error.
+func handle(t *testing.T, h lx.Handler, e *lx.Entry) (err error) {
+	t.Helper()
+	defer func() {
+		if r := recover(); r != nil {
+			t.Errorf("Handle panicked on class %v, message %.40q: %v", e.Class, e.Message, r)
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return h.Handle(e)
+}
+
+// closeHandler closes h if it implements a Close() error method, flushing any buffered
+// output, and reports whether it did.
+func closeHandler(t *testing.T, h lx.Handler) bool {
+	t.Helper()
+	c, ok := h.(interface{ Close() error })
+	if !ok {
+		return false
+	}
+	if err := c.Close(); err != nil {
+		t.Errorf("Close returned an error: %v", err)
+	}
+	return true
+}
+
+// recorded returns the messages of entries kept by handlers exposing them, such as
+// lh.MemoryHandler.
+func recorded(h lx.Handler) ([]string, bool) {
+	r, ok := h.(interface{ Entries() []*lx.Entry })
+	if !ok {
+		return nil, false
+	}
+	var msgs []string
+	for _, e := range r.Entries() {
+		msgs = append(msgs, e.Message)
+	}
+	return msgs, true
+}
+
+// testClasses checks that every class is accepted, including malformed dumps.
+func testClasses(t *testing.T, factory Factory) {
+	h := factory(&syncBuffer{})
+	defer closeHandler(t, h)
+
+	payloads := map[lx.ClassType][]string{
+		lx.ClassDump: {
+			"pos 00  hex:  68  65  6c  6c  6f                        'hello'\n",
+			"pos 00  hex:",
+			"pos",
+			"not a dump",
+			"",
+		},
+		lx.ClassJSON:   {`{"a": 1}`, `{not json`},
+		lx.ClassOutput: {"[main.go:1] JSON:\n{\n    \"a\": 1\n  }"},
+		lx.ClassStack:  {"goroutine 1 [running]:\nmain.main()\n\t/app/main.go:10 +0x1d"},
+		lx.ClassRaw:    {"raw\n", ""},
+	}
+	for class := lx.ClassText; class <= lx.ClassUnknown; class++ {
+		msgs := payloads[class]
+		if msgs == nil {
+			msgs = []string{"message of class " + class.String()}
+		}
+		for _, msg := range msgs {
+			e := entry(class, msg)
+			if class == lx.ClassStack {
+				e.Stack = []byte(msg)
+			}
+			if err := handle(t, h, e); err != nil {
+				t.Errorf("Handle returned an error for class %v, message %.40q: %v", class, msg, err)
+			}
+		}
+	}
+	for _, level := range []lx.LevelType{lx.LevelNone, lx.LevelDebug, lx.LevelWarn, lx.LevelError, lx.LevelFatal, lx.LevelUnknown} {
+		e := entry(lx.ClassText, "message at "+level.String())
+		e.Level = level
+		if err := handle(t, h, e); err != nil {
+			t.Errorf("Handle returned an error at level %v: %v", level, err)
+		}
+	}
+}
+
+// testEmptyValues checks entries with nil and empty values.
+func testEmptyValues(t *testing.T, factory Factory) {
+	h := factory(&syncBuffer{})
+	defer closeHandler(t, h)
+
+	for name, e := range map[string]*lx.Entry{
+		"zero entry":   {},
+		"nil fields":   {Message: "nil fields", Level: lx.LevelInfo},
+		"empty fields": {Message: "empty fields", Level: lx.LevelInfo, Fields: lx.Fields{}},
+		"empty key":    {Message: "empty key", Level: lx.LevelInfo, Fields: lx.Fields{lx.String("", "v")}},
+		"nil value":    {Message: "nil value", Level: lx.LevelInfo, Fields: lx.Fields{lx.Any("k", nil)}},
+		"nil error":    {Message: "nil error", Level: lx.LevelError, Fields: lx.Fields{lx.Err("error", nil)}},
+		"empty stack":  {Message: "empty stack", Level: lx.LevelError, Class: lx.ClassStack, Stack: []byte{}},
+		"with error":   {Message: "with error", Level: lx.LevelError, Error: errors.New("boom")},
+	} {
+		if err := handle(t, h, e); err != nil {
+			t.Errorf("Handle returned an error for %s: %v", name, err)
+		}
+	}
+}
+
+// testLargeEntries checks very large messages and many fields.
+func testLargeEntries(t *testing.T, factory Factory) {
+	h := factory(&syncBuffer{})
+	defer closeHandler(t, h)
+
+	big := entry(lx.ClassText, strings.Repeat("x", 4<<20))
+	if err := handle(t, h, big); err != nil {
+		t.Errorf("Handle returned an error for a 4MB message: %v", err)
+	}
+	many := entry(lx.ClassText, "many fields")
+	for i := 0; i < 1000; i++ {
+		many.Fields = append(many.Fields, lx.Int(fmt.Sprintf("f%d", i), i))
+	}
+	if err := handle(t, h, many); err != nil {
+		t.Errorf("Handle returned an error for 1000 fields: %v", err)
+	}
+	long := entry(lx.ClassText, "long field")
+	long.Fields = lx.Fields{lx.String("payload", strings.Repeat("y", 1<<20))}
+	if err := handle(t, h, long); err != nil {
+		t.Errorf("Handle returned an error for a 1MB field: %v", err)
+	}
+}
+
+// testConcurrent checks concurrent Handle calls; data races are reported under -race.
+func testConcurrent(t *testing.T, factory Factory) {
+	h := factory(&syncBuffer{})
+	defer closeHandler(t, h)
+
+	const goroutines, perGoroutine = 8, 200
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < perGoroutine; i++ {
+				class := lx.ClassType(i % int(lx.ClassUnknown+1))
+				e := entry(class, fmt.Sprintf("goroutine %d entry %d", g, i))
+				if class == lx.ClassDump {
+					e.Message = "pos 00  hex:  61  'a'\n"
+				}
+				if err := h.Handle(e); err != nil {
+					t.Errorf("concurrent Handle returned an error: %v", err)
+					return
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+}
+
+// testEntryReuse checks that the handler does not retain entries: each entry is
+// overwritten after Handle returns, as the logger's pool does, and the output must
+// still reflect the original content.
+func testEntryReuse(t *testing.T, factory Factory) {
+	buf := &syncBuffer{}
+	h := factory(buf)
+
+	const n = 20
+	e := &lx.Entry{Fields: make(lx.Fields, 1), Stack: make([]byte, 0, 64)}
+	for i := 0; i < n; i++ {
+		e.Timestamp = time.Now()
+		e.Level = lx.LevelInfo
+		e.Class = lx.ClassText
+		e.Namespace = "app"
+		e.Message = fmt.Sprintf("reuse-message-%d", i)
+		e.Fields = e.Fields[:1]
+		e.Fields[0] = lx.String("reuse_field", fmt.Sprintf("reuse-value-%d", i))
+		if err := handle(t, h, e); err != nil {
+			t.Fatalf("Handle returned an error: %v", err)
+		}
+		// Poison everything the handler may have kept a reference to.
+		e.Message = "poisoned"
+		e.Fields[0] = lx.String("reuse_field", "poisoned")
+	}
+	msgs, keeps := recorded(h)
+	closeHandler(t, h)
+
+	if keeps {
+		if len(msgs) != n {
+			t.Fatalf("expected %d recorded entries, got %d", n, len(msgs))
+		}
+		for i, msg := range msgs {
+			if want := fmt.Sprintf("reuse-message-%d", i); msg != want {
+				t.Errorf("recorded entry %d was overwritten after Handle returned: got %q, want %q", i, msg, want)
+			}
+		}
+		return
+	}
+	out := buf.String()
+	if out == "" {
+		t.Skip("handler writes neither to the factory's writer nor exposes Entries")
+	}
+	if strings.Contains(out, "poisoned") {
+		t.Errorf("output contains content written to the entry after Handle returned; the handler retained it:\n%.500s", out)
+	}
+	for i := 0; i < n; i++ {
+		if want := fmt.Sprintf("reuse-message-%d", i); !strings.Contains(out, want) {
+			t.Errorf("output is missing %q", want)
+		}
+	}
+}
+
+// testOutputter checks that Output redirects subsequent output.
+func testOutputter(t *testing.T, factory Factory) {
+	first := &syncBuffer{}
+	h := factory(first)
+	o, ok := h.(lx.Outputter)
+	if !ok {
+		t.Skip("handler does not implement lx.Outputter")
+	}
+
+	second := &syncBuffer{}
+	o.Output(second)
+	if err := handle(t, h, entry(lx.ClassText, "after-output-switch")); err != nil {
+		t.Fatalf("Handle returned an error: %v", err)
+	}
+	closeHandler(t, h)
+	if strings.Contains(first.String(), "after-output-switch") {
+		t.Error("entry written to the previous writer after Output")
+	}
+	if !strings.Contains(second.String(), "after-output-switch") {
+		t.Error("entry not written to the writer set by Output")
+	}
+}
+
+// testTimestamper checks that Timestamped applies the requested format.
+func testTimestamper(t *testing.T, factory Factory) {
+	buf := &syncBuffer{}
+	h := factory(buf)
+	ts, ok := h.(lx.Timestamper)
+	if !ok {
+		t.Skip("handler does not implement lx.Timestamper")
+	}
+
+	ts.Timestamped(true, "2006@01@02")
+	e := entry(lx.ClassText, "timestamped")
+	e.Timestamp = time.Date(1999, 12, 31, 23, 59, 0, 0, time.UTC)
+	if err := handle(t, h, e); err != nil {
+		t.Fatalf("Handle returned an error: %v", err)
+	}
+	ts.Timestamped(false)
+	if err := handle(t, h, entry(lx.ClassText, "untimestamped")); err != nil {
+		t.Fatalf("Handle returned an error: %v", err)
+	}
+	closeHandler(t, h)
+
+	out := buf.String()
+	if out == "" {
+		t.Skip("handler does not write to the factory's writer")
+	}
+	if !strings.Contains(out, "1999@12@31") {
+		t.Errorf("output does not use the format passed to Timestamped:\n%.500s", out)
+	}
+}
+
+// testClose checks that Close may be called repeatedly and that Handle after Close
+// does not panic.
+func testClose(t *testing.T, factory Factory) {
+	h := factory(&syncBuffer{})
+	c, ok := h.(interface{ Close() error })
+	if !ok {
+		t.Skip("handler does not implement Close")
+	}
+	if err := handle(t, h, entry(lx.ClassText, "before close")); err != nil {
+		t.Fatalf("Handle returned an error: %v", err)
+	}
+	if err := c.Close(); err != nil {
+		t.Errorf("first Close returned an error: %v", err)
+	}
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				t.Errorf("second Close panicked: %v", r)
+			}
+		}()
+		if err := c.Close(); err != nil {
+			t.Errorf("second Close returned an error: %v", err)
+		}
+	}()
+	handle(t, h, entry(lx.ClassText, "after close")) // May fail, must not panic
+}
@@ -0,0 +1,39 @@
+package lhtest
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/olekukonko/ll/lh"
+	"github.com/olekukonko/ll/lx"
+)
+
+// TestBuiltinHandlers runs the conformance suite against the handlers in lh.
+func TestBuiltinHandlers(t *testing.T) {
+	for name, factory := range map[string]Factory{
+		"Text":       func(w io.Writer) lx.Handler { return lh.NewTextHandler(w) },
+		"JSON":       func(w io.Writer) lx.Handler { return lh.NewJSONHandler(w) },
+		"Colorized":  func(w io.Writer) lx.Handler { return lh.NewColorizedHandler(w) },
+		"Memory":     func(w io.Writer) lx.Handler { return lh.NewMemoryHandler() },
+		"MemoryRing": func(w io.Writer) lx.Handler { return lh.NewMemoryHandler(lh.WithMemoryCapacity(64)) },
+		"Access":     func(w io.Writer) lx.Handler { return lh.NewAccessLogHandler(w, lh.AccessCombined) },
+		"Multi": func(w io.Writer) lx.Handler {
+			return lh.NewMultiHandler(lh.NewTextHandler(w), lh.NewMemoryHandler())
+		},
+		"Buffered": func(w io.Writer) lx.Handler {
+			// Room for the whole Concurrent load, so backpressure never rejects entries
+			return lh.NewBuffered(lh.NewTextHandler(w), lh.WithBatchSize(7), lh.WithMaxBuffer(2048))
+		},
+		"Flight": func(w io.Writer) lx.Handler {
+			return lh.NewFlightRecorder().Wrap(lh.NewTextHandler(w))
+		},
+		"Dedup": func(w io.Writer) lx.Handler {
+			return lh.NewDedup(lh.NewTextHandler(w), time.Minute)
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			RunConformance(t, factory)
+		})
+	}
+}
@@ -3,19 +3,43 @@ package lh
 import (
 	"fmt"
 	"io"
+	"net/http"
+	"strconv"
+	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/olekukonko/ll/lx"
 )
 
+// MemoryOption configures a MemoryHandler.
+type MemoryOption func(*MemoryHandler)
+
+// WithMemoryCapacity bounds the handler to the most recent n entries: once full, each
+// new entry evicts the oldest, which is counted by Dropped. A capacity of 0 (the
+// default) keeps every entry.
+func WithMemoryCapacity(n int) MemoryOption {
+	return func(h *MemoryHandler) {
+		if n > 0 {
+			h.capacity = n
+		}
+	}
+}
+
 // MemoryHandler is an lx.Handler that stores log entries in memory.
-// Useful for testing or buffering logs for later inspection.
+// Useful for testing, buffering logs for later inspection, or as an in-process log
+// store behind a debug endpoint: bounded with WithMemoryCapacity, it acts as a ring
+// buffer of recent entries that can be filtered with Query and exported as NDJSON.
 // It maintains a thread-safe slice of log entries, protected by a read-write mutex.
 type MemoryHandler struct {
 	mu         sync.RWMutex // Protects concurrent access to entries
-	entries    []*lx.Entry  // Slice of stored log entries
-	showTime   bool         // Whether to show timestamps when dumping
-	timeFormat string       // Time format for dumping
+	entries    []*lx.Entry  // Stored log entries; a ring starting at head once full
+	head       int          // Index of the oldest entry when the ring is full
+	capacity   int          // Maximum number of entries, 0 for unbounded
+	dropped    atomic.Uint64
+	showTime   bool   // Whether to show timestamps when dumping
+	timeFormat string // Time format for dumping
 }
 
 // NewMemoryHandler creates a new MemoryHandler.
@@ -25,10 +49,15 @@ type MemoryHandler struct {
 //	handler := NewMemoryHandler()
 //	logger := ll.New("app").Enable().Handler(handler)
 //	logger.Info("Test") // Stores entry in memory
-func NewMemoryHandler() *MemoryHandler {
-	return &MemoryHandler{
-		entries: make([]*lx.Entry, 0), // Initialize empty slice for entries
+//
+//	recent := NewMemoryHandler(WithMemoryCapacity(1000)) // Keeps the last 1000 entries
+func NewMemoryHandler(opts ...MemoryOption) *MemoryHandler {
+	h := &MemoryHandler{}
+	for _, opt := range opts {
+		opt(h)
 	}
+	h.entries = make([]*lx.Entry, 0, h.capacity) // Initialize empty slice for entries
+	return h
 }
 
 // Timestamped enables/disables timestamp display when dumping and optionally sets a time format.
@@ -48,20 +77,29 @@ func (h *MemoryHandler) Timestamped(enable bool, format ...string) {
 	}
 }
 
-// Handle stores the log entry in memory.
-// It appends the provided entry to the entries slice, ensuring thread-safety with a write lock.
+// Handle stores a copy of the log entry in memory.
+// Entries handed to handlers are pooled and reused by the logger, so the entry is cloned
+// before being appended, ensuring thread-safety with a write lock. When the handler is
+// full, the oldest entry is evicted.
 // Always returns nil, as it does not perform I/O operations.
 // Example:
 //
 //	handler.Handle(&lx.Entry{Message: "test", Level: lx.LevelInfo}) // Stores entry
 func (h *MemoryHandler) Handle(entry *lx.Entry) error {
+	entryCopy := cloneEntry(entry) // The logger reuses entry once Handle returns
 	h.mu.Lock()
 	defer h.mu.Unlock()
-	h.entries = append(h.entries, entry) // Append entry to slice
+	if h.capacity > 0 && len(h.entries) == h.capacity {
+		h.entries[h.head] = entryCopy // Overwrite the oldest entry
+		h.head = (h.head + 1) % h.capacity
+		h.dropped.Add(1)
+		return nil
+	}
+	h.entries = append(h.entries, entryCopy) // Append entry to slice
 	return nil
 }
 
-// Entries returns a copy of the stored log entries.
+// Entries returns a copy of the stored log entries, oldest first.
 // It creates a new slice with copies of all entries, ensuring thread-safety with a read lock.
 // The returned slice is safe for external use without affecting the handler's internal state.
 // Example:
@@ -70,9 +108,19 @@ func (h *MemoryHandler) Handle(entry *lx.Entry) error {
 func (h *MemoryHandler) Entries() []*lx.Entry {
 	h.mu.RLock()
 	defer h.mu.RUnlock()
-	entries := make([]*lx.Entry, len(h.entries)) // Create new slice for copy
-	copy(entries, h.entries)                     // Copy entries to new slice
-	return entries
+	return h.snapshotLocked()
+}
+
+// Len returns the number of stored entries.
+func (h *MemoryHandler) Len() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.entries)
+}
+
+// Dropped returns the number of entries evicted because the handler was full.
+func (h *MemoryHandler) Dropped() uint64 {
+	return h.dropped.Load()
 }
 
 // Reset clears all stored entries.
@@ -83,7 +131,9 @@ func (h *MemoryHandler) Entries() []*lx.Entry {
 func (h *MemoryHandler) Reset() {
 	h.mu.Lock()
 	defer h.mu.Unlock()
+	clear(h.entries)          // Release references to evicted entries
 	h.entries = h.entries[:0] // Truncate slice to zero length
+	h.head = 0
 }
 
 // Dump writes all stored log entries to the provided io.Writer in text format.
@@ -105,10 +155,257 @@ func (h *MemoryHandler) Dump(w io.Writer) error {
 	tempHandler.Timestamped(h.showTime, h.timeFormat)
 
 	// Process each entry through the TextHandler
-	for _, entry := range h.entries {
+	for _, entry := range h.snapshotLocked() {
 		if err := tempHandler.Handle(entry); err != nil {
-			return fmt.Errorf("failed to dump entry: %writer", err) // Wrap and return write errors
+			return fmt.Errorf("failed to dump entry: %w", err) // Wrap and return write errors
+		}
+	}
+	return nil
+}
+
+// WriteNDJSON writes all stored entries to w as newline-delimited JSON, one object per
+// entry in the JSONHandler format, oldest first.
+func (h *MemoryHandler) WriteNDJSON(w io.Writer) error {
+	return h.Query().WriteNDJSON(w)
+}
+
+// Query starts a query over the stored entries. Conditions added to it must all hold.
+// Example:
+//
+//	errs := handler.Query().MinLevel(lx.LevelWarn).Namespace("app/db").Since(time.Now().Add(-time.Hour)).Entries()
+func (h *MemoryHandler) Query() *MemoryQuery {
+	return &MemoryQuery{handler: h}
+}
+
+// ServeHTTP serves the stored entries as NDJSON, filtered by the query parameters
+// min_level and max_level (level names), namespace (prefix or glob), class (class
+// names, comma-separated), since and until (RFC 3339 times, or durations before now
+// such as "15m"), and limit (most recent entries). Invalid values are rejected with
+// 400 Bad Request.
+// Example:
+//
+//	http.Handle("/debug/logs", handler) // GET /debug/logs?min_level=warn&since=15m&limit=100
+func (h *MemoryHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	q := h.Query()
+	params := r.URL.Query()
+	for _, p := range []struct {
+		name string
+		set  func(lx.LevelType) *MemoryQuery
+	}{{"min_level", q.MinLevel}, {"max_level", q.MaxLevel}} {
+		v := params.Get(p.name)
+		if v == "" {
+			continue
+		}
+		level := lx.LevelParse(v)
+		if level.Severity() < 0 {
+			http.Error(w, "invalid "+p.name+": "+v, http.StatusBadRequest)
+			return
+		}
+		p.set(level)
+	}
+	if v := params.Get("namespace"); v != "" {
+		q.Namespace(v)
+	}
+	if v := params.Get("class"); v != "" {
+		var classes []lx.ClassType
+		for _, name := range strings.Split(v, ",") {
+			class := parseClass(name)
+			if class == lx.ClassUnknown {
+				http.Error(w, "invalid class: "+name, http.StatusBadRequest)
+				return
+			}
+			classes = append(classes, class)
+		}
+		q.Class(classes...)
+	}
+	for _, p := range []struct {
+		name string
+		set  func(time.Time) *MemoryQuery
+	}{{"since", q.Since}, {"until", q.Until}} {
+		v := params.Get(p.name)
+		if v == "" {
+			continue
+		}
+		t, err := parseQueryTime(v)
+		if err != nil {
+			http.Error(w, "invalid "+p.name+": "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		p.set(t)
+	}
+	if v := params.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			http.Error(w, "invalid limit", http.StatusBadRequest)
+			return
+		}
+		q.Limit(n)
+	}
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	q.WriteNDJSON(w)
+}
+
+// snapshotLocked returns the stored entries in insertion order.
+// Caller must hold the lock.
+func (h *MemoryHandler) snapshotLocked() []*lx.Entry {
+	entries := make([]*lx.Entry, 0, len(h.entries)) // Create new slice for copy
+	entries = append(entries, h.entries[h.head:]...)
+	return append(entries, h.entries[:h.head]...)
+}
+
+// MemoryQuery filters the entries of a MemoryHandler. Build it with MemoryHandler.Query
+// and read the result with Entries, Count or WriteNDJSON; each reads the entries stored
+// at the time of the call.
+type MemoryQuery struct {
+	handler    *MemoryHandler
+	conditions []func(*lx.Entry) bool
+	limit      int
+}
+
+// Levels restricts the query to entries from min to max severity inclusive, using the
+// order Debug < Info < Warn < Error < Fatal. Entries with LevelNone or LevelUnknown
+// never satisfy this condition.
+func (q *MemoryQuery) Levels(min, max lx.LevelType) *MemoryQuery {
+	return q.MinLevel(min).MaxLevel(max)
+}
+
+// MinLevel restricts the query to entries at least as severe as level.
+func (q *MemoryQuery) MinLevel(level lx.LevelType) *MemoryQuery {
+	min := level.Severity()
+	return q.Match(func(e *lx.Entry) bool {
+		s := e.Level.Severity()
+		return s >= 0 && s >= min
+	})
+}
+
+// MaxLevel restricts the query to entries at most as severe as level.
+func (q *MemoryQuery) MaxLevel(level lx.LevelType) *MemoryQuery {
+	max := level.Severity()
+	return q.Match(func(e *lx.Entry) bool {
+		s := e.Level.Severity()
+		return s >= 0 && s <= max
+	})
+}
+
+// Namespace restricts the query to entries whose namespace matches any pattern, as
+// Route.Namespace does: a prefix matching the namespace and its children, or a glob.
+func (q *MemoryQuery) Namespace(patterns ...string) *MemoryQuery {
+	return q.Match(func(e *lx.Entry) bool {
+		for _, p := range patterns {
+			if matchNamespace(p, e.Namespace) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// Since restricts the query to entries logged at or after t.
+func (q *MemoryQuery) Since(t time.Time) *MemoryQuery {
+	return q.Match(func(e *lx.Entry) bool {
+		return !e.Timestamp.Before(t)
+	})
+}
+
+// Until restricts the query to entries logged before t.
+func (q *MemoryQuery) Until(t time.Time) *MemoryQuery {
+	return q.Match(func(e *lx.Entry) bool {
+		return e.Timestamp.Before(t)
+	})
+}
+
+// Class restricts the query to entries of the given classes.
+func (q *MemoryQuery) Class(classes ...lx.ClassType) *MemoryQuery {
+	return q.Match(func(e *lx.Entry) bool {
+		for _, c := range classes {
+			if e.Class == c {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// Field restricts the query to entries carrying a field named key whose value
+// satisfies pred. A nil pred only requires the field to be present.
+func (q *MemoryQuery) Field(key string, pred func(value interface{}) bool) *MemoryQuery {
+	return q.Match(func(e *lx.Entry) bool {
+		v, ok := e.Fields.Get(key)
+		if !ok {
+			return false
+		}
+		return pred == nil || pred(v)
+	})
+}
+
+// Match restricts the query with an arbitrary predicate over the entry.
+func (q *MemoryQuery) Match(pred func(e *lx.Entry) bool) *MemoryQuery {
+	q.conditions = append(q.conditions, pred)
+	return q
+}
+
+// Limit restricts the result to the n most recent matching entries. 0 means no limit.
+func (q *MemoryQuery) Limit(n int) *MemoryQuery {
+	q.limit = n
+	return q
+}
+
+// Entries returns the matching entries, oldest first.
+func (q *MemoryQuery) Entries() []*lx.Entry {
+	var matched []*lx.Entry
+	for _, e := range q.handler.Entries() {
+		if q.matches(e) {
+			matched = append(matched, e)
+		}
+	}
+	if q.limit > 0 && len(matched) > q.limit {
+		matched = matched[len(matched)-q.limit:]
+	}
+	return matched
+}
+
+// Count returns the number of matching entries.
+func (q *MemoryQuery) Count() int {
+	return len(q.Entries())
+}
+
+// WriteNDJSON writes the matching entries to w as newline-delimited JSON, one object
+// per entry in the JSONHandler format, oldest first.
+func (q *MemoryQuery) WriteNDJSON(w io.Writer) error {
+	jh := NewJSONHandler(w)
+	for _, e := range q.Entries() {
+		if err := jh.Handle(e); err != nil {
+			return err
 		}
 	}
 	return nil
 }
+
+// matches reports whether every condition of the query holds for the entry.
+func (q *MemoryQuery) matches(e *lx.Entry) bool {
+	for _, cond := range q.conditions {
+		if !cond(e) {
+			return false
+		}
+	}
+	return true
+}
+
+// parseClass converts a class name, as returned by ClassType.String, to its ClassType.
+func parseClass(name string) lx.ClassType {
+	name = strings.ToUpper(strings.TrimSpace(name))
+	for c := lx.ClassText; c < lx.ClassUnknown; c++ {
+		if c.String() == name {
+			return c
+		}
+	}
+	return lx.ClassUnknown
+}
+
+// parseQueryTime parses an RFC 3339 time, or a duration meaning that long before now.
+func parseQueryTime(v string) (time.Time, error) {
+	if d, err := time.ParseDuration(v); err == nil {
+		return time.Now().Add(-d), nil
+	}
+	return time.Parse(time.RFC3339, v)
+}
@@ -0,0 +1,159 @@
+package lh
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/olekukonko/ll/lx"
+)
+
+// TestMemory_Capacity tests that a bounded handler keeps the most recent entries in order
+func TestMemory_Capacity(t *testing.T) {
+	h := NewMemoryHandler(WithMemoryCapacity(3))
+	for i := 0; i < 5; i++ {
+		h.Handle(&lx.Entry{Level: lx.LevelInfo, Message: fmt.Sprintf("m%d", i)})
+	}
+
+	entries := h.Entries()
+	if len(entries) != 3 || h.Len() != 3 {
+		t.Fatalf("expected 3 entries, got %d", len(entries))
+	}
+	for i, e := range entries {
+		if want := fmt.Sprintf("m%d", i+2); e.Message != want {
+			t.Errorf("entry %d: expected %q, got %q", i, want, e.Message)
+		}
+	}
+	if h.Dropped() != 2 {
+		t.Errorf("expected 2 dropped entries, got %d", h.Dropped())
+	}
+
+	h.Reset()
+	h.Handle(&lx.Entry{Level: lx.LevelInfo, Message: "after reset"})
+	if entries := h.Entries(); len(entries) != 1 || entries[0].Message != "after reset" {
+		t.Errorf("unexpected entries after reset: %v", entries)
+	}
+}
+
+// TestMemory_Clones tests that stored entries are not affected by reuse of the original
+func TestMemory_Clones(t *testing.T) {
+	h := NewMemoryHandler()
+	e := &lx.Entry{Message: "first", Fields: lx.Fields{lx.String("k", "v1")}}
+	h.Handle(e)
+	e.Message = "second"
+	e.Fields[0] = lx.String("k", "v2")
+
+	stored := h.Entries()[0]
+	if v, _ := stored.Fields.Get("k"); stored.Message != "first" || v != "v1" {
+		t.Errorf("stored entry changed with the original: %q %v", stored.Message, v)
+	}
+}
+
+// TestMemory_Query tests filtering by level range, namespace, time, class and fields
+func TestMemory_Query(t *testing.T) {
+	h := NewMemoryHandler()
+	base := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	for i, e := range []lx.Entry{
+		{Level: lx.LevelDebug, Namespace: "app", Message: "debug"},
+		{Level: lx.LevelInfo, Namespace: "app/db", Message: "query", Fields: lx.Fields{lx.Int("rows", 10)}},
+		{Level: lx.LevelWarn, Namespace: "app/db", Message: "slow", Fields: lx.Fields{lx.Int("rows", 5000)}},
+		{Level: lx.LevelError, Namespace: "app/http", Message: "failed"},
+		{Level: lx.LevelInfo, Namespace: "app/dbx", Message: "other", Class: lx.ClassDump},
+	} {
+		e.Timestamp = base.Add(time.Duration(i) * time.Minute)
+		h.Handle(&e)
+	}
+
+	messages := func(q *MemoryQuery) []string {
+		var msgs []string
+		for _, e := range q.Entries() {
+			msgs = append(msgs, e.Message)
+		}
+		return msgs
+	}
+	for name, tc := range map[string]struct {
+		query *MemoryQuery
+		want  []string
+	}{
+		"min level":   {h.Query().MinLevel(lx.LevelWarn), []string{"slow", "failed"}},
+		"level range": {h.Query().Levels(lx.LevelInfo, lx.LevelWarn), []string{"query", "slow", "other"}},
+		"namespace":   {h.Query().Namespace("app/db"), []string{"query", "slow"}},
+		"glob":        {h.Query().Namespace("app/d*"), []string{"query", "slow", "other"}},
+		"time window": {h.Query().Since(base.Add(time.Minute)).Until(base.Add(3 * time.Minute)), []string{"query", "slow"}},
+		"class":       {h.Query().Class(lx.ClassDump), []string{"other"}},
+		"field": {h.Query().Field("rows", func(v interface{}) bool {
+			n, ok := v.(int64)
+			return ok && n > 1000
+		}), []string{"slow"}},
+		"limit": {h.Query().MaxLevel(lx.LevelInfo).Limit(2), []string{"query", "other"}},
+	} {
+		if got := messages(tc.query); fmt.Sprint(got) != fmt.Sprint(tc.want) {
+			t.Errorf("%s: expected %v, got %v", name, tc.want, got)
+		}
+	}
+}
+
+// TestMemory_NDJSON tests NDJSON export and the HTTP endpoint
+func TestMemory_NDJSON(t *testing.T) {
+	h := NewMemoryHandler()
+	h.Handle(&lx.Entry{Level: lx.LevelInfo, Namespace: "app", Message: "started", Timestamp: time.Now()})
+	h.Handle(&lx.Entry{Level: lx.LevelError, Namespace: "app", Message: "failed", Timestamp: time.Now()})
+
+	var buf bytes.Buffer
+	if err := h.WriteNDJSON(&buf); err != nil {
+		t.Fatalf("WriteNDJSON failed: %v", err)
+	}
+	if lines := decodeNDJSON(t, buf.Bytes()); len(lines) != 2 || lines[0]["msg"] != "started" {
+		t.Errorf("unexpected NDJSON: %s", buf.String())
+	}
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("GET", "/debug/logs?min_level=warn&since=1h&namespace=app", nil))
+	if ct := rec.Header().Get("Content-Type"); ct != "application/x-ndjson" {
+		t.Errorf("unexpected content type %q", ct)
+	}
+	if lines := decodeNDJSON(t, rec.Body.Bytes()); len(lines) != 1 || lines[0]["msg"] != "failed" {
+		t.Errorf("unexpected response: %s", rec.Body.String())
+	}
+
+	h.Handle(&lx.Entry{Level: lx.LevelFatal, Namespace: "app", Message: "crashed", Timestamp: time.Now()})
+	for query, want := range map[string]string{
+		"min_level=fatal":                 "crashed",
+		"max_level=fatal&limit=1":         "crashed",
+		"max_level=info":                  "started",
+		"min_level=ERROR&max_level=error": "failed",
+	} {
+		rec = httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest("GET", "/debug/logs?"+query, nil))
+		if lines := decodeNDJSON(t, rec.Body.Bytes()); len(lines) != 1 || lines[0]["msg"] != want {
+			t.Errorf("%s: expected only %q, got %s", query, want, rec.Body.String())
+		}
+	}
+
+	for _, query := range []string{"since=yesterday", "min_level=critical", "max_level=none", "class=text,dumps", "limit=-1"} {
+		rec = httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest("GET", "/debug/logs?"+query, nil))
+		if rec.Code != 400 {
+			t.Errorf("%s: expected 400, got %d", query, rec.Code)
+		}
+	}
+}
+
+// decodeNDJSON decodes one JSON object per line
+func decodeNDJSON(t *testing.T, data []byte) []map[string]interface{} {
+	t.Helper()
+	var lines []map[string]interface{}
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	for sc.Scan() {
+		var m map[string]interface{}
+		if err := json.Unmarshal(sc.Bytes(), &m); err != nil {
+			t.Fatalf("invalid NDJSON line %q: %v", sc.Text(), err)
+		}
+		lines = append(lines, m)
+	}
+	return lines
+}
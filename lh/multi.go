@@ -3,16 +3,129 @@ package lh
 import (
 	"errors"
 	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/olekukonko/ll/lx"
 )
 
+// ErrMultiDropped is reported when an asynchronous MultiHandler child cannot accept an
+// entry because its queue stayed full for longer than the configured timeout.
+var ErrMultiDropped = errors.New("entry dropped: handler queue full")
+
+// ErrMultiTimeout is reported when an asynchronous MultiHandler child takes longer than
+// its handle timeout to process an entry.
+var ErrMultiTimeout = errors.New("handler timed out")
+
+// ErrMultiClosed is returned by an asynchronous MultiHandler after Close has been called.
+var ErrMultiClosed = errors.New("multi handler closed")
+
+// MultiAsync holds configuration for the asynchronous fan-out mode of MultiHandler.
+// Options given with WithAsyncChild override these values for a single child.
+type MultiAsync struct {
+	QueueSize     int           // Per-handler queue capacity (default: 1024)
+	Timeout       time.Duration // Maximum time Handle waits for space in a full queue before dropping (default: 0, drop immediately)
+	HandleTimeout time.Duration // Maximum time a child's Handle may run before it is abandoned (default: 0, unbounded)
+
+	children map[int][]MultiAsyncOpt // Per-child overrides, by index in Handlers
+}
+
+// MultiAsyncOpt configures the asynchronous mode of MultiHandler.
+type MultiAsyncOpt func(*MultiAsync)
+
+// WithAsyncQueueSize sets the capacity of each handler's queue.
+func WithAsyncQueueSize(size int) MultiAsyncOpt {
+	return func(c *MultiAsync) {
+		c.QueueSize = size
+	}
+}
+
+// WithAsyncTimeout sets how long Handle waits for space in a full handler queue
+// before the entry is dropped for that handler.
+func WithAsyncTimeout(d time.Duration) MultiAsyncOpt {
+	return func(c *MultiAsync) {
+		c.Timeout = d
+	}
+}
+
+// WithAsyncHandleTimeout bounds the time a handler may spend in Handle. When a call
+// exceeds d, the worker stops waiting for it and counts a timeout; entries reaching the
+// handler while the abandoned call is still running are dropped, so a stalled sink
+// (for example a hung HTTP POST) costs one goroutine rather than a blocked worker.
+func WithAsyncHandleTimeout(d time.Duration) MultiAsyncOpt {
+	return func(c *MultiAsync) {
+		c.HandleTimeout = d
+	}
+}
+
+// WithAsyncChild applies opts to the handler at index in Handlers only, overriding the
+// options shared by all handlers.
+// Example:
+//
+//	multi := lh.NewMultiHandler(console, victoria).Async(
+//	    lh.WithAsyncChild(1, lh.WithAsyncQueueSize(4096), lh.WithAsyncHandleTimeout(2*time.Second)),
+//	)
+func WithAsyncChild(index int, opts ...MultiAsyncOpt) MultiAsyncOpt {
+	return func(c *MultiAsync) {
+		if c.children == nil {
+			c.children = make(map[int][]MultiAsyncOpt)
+		}
+		c.children[index] = append(c.children[index], opts...)
+	}
+}
+
+// MultiStats reports per-handler activity for an asynchronous MultiHandler.
+type MultiStats struct {
+	Index    int    // Position of the handler in Handlers
+	Handled  uint64 // Entries processed by the handler (successfully or not)
+	Errors   uint64 // Entries for which the handler returned an error
+	Dropped  uint64 // Entries dropped because the handler's queue was full or a timed-out call was still running
+	Timeouts uint64 // Handle calls abandoned after exceeding the handle timeout
+	Queued   int    // Entries currently waiting in the handler's queue
+}
+
 // MultiHandler combines multiple handlers to process log entries concurrently.
 // It holds a list of lx.Handler instances and delegates each log entry to all handlers,
 // collecting any errors into a single combined error.
+// By default handlers are called in sequence on the caller's goroutine; after Async is
+// called, each handler gets its own bounded queue and worker so a slow sink cannot
+// block the others.
 // Thread-safe if the underlying handlers are thread-safe.
 type MultiHandler struct {
 	Handlers []lx.Handler // List of handlers to process each log entry
+
+	mu      sync.RWMutex   // Guards Handlers, and workers and closed in async mode
+	config  *MultiAsync    // Async configuration, nil in synchronous mode
+	workers []*multiWorker // One worker per handler in async mode
+	closed  bool
+	wg      sync.WaitGroup
+}
+
+// multiItem is a queued unit of work: either an entry to handle or a flush marker.
+type multiItem struct {
+	entry *lx.Entry
+	ack   chan struct{} // Closed by the worker once every earlier item has been handled
+}
+
+// multiWorker owns the queue and counters for a single child handler.
+type multiWorker struct {
+	index         int
+	handler       lx.Handler
+	queue         chan multiItem
+	timeout       time.Duration // Wait for queue space
+	handleTimeout time.Duration // Bound on a Handle call, 0 for none
+	done          chan struct{} // Closed by MultiHandler.Close to stop the worker
+	exited        chan struct{} // Closed by the worker once it has stopped
+	pending       chan error    // Result of a timed-out Handle call still running, owned by run
+
+	handled  atomic.Uint64
+	errors   atomic.Uint64
+	dropped  atomic.Uint64
+	timeouts atomic.Uint64
+
+	errMu   sync.Mutex
+	lastErr error // Most recent handler error not yet reported by Handle
 }
 
 // NewMultiHandler creates a new MultiHandler with the specified handlers.
@@ -31,6 +144,39 @@ func NewMultiHandler(h ...lx.Handler) *MultiHandler {
 	}
 }
 
+// Async switches the MultiHandler to asynchronous fan-out mode and returns it for chaining.
+// Every handler (including ones added later with Append) gets a bounded queue and a
+// dedicated worker goroutine. Handle copies the entry, enqueues it for each handler and
+// returns without waiting for the handlers to run. Calling Async more than once has no effect.
+// Example:
+//
+//	multi := lh.NewMultiHandler(console, victoria).Async(
+//	    lh.WithAsyncQueueSize(512),
+//	    lh.WithAsyncTimeout(5*time.Millisecond),
+//	)
+//	defer multi.Close()
+func (h *MultiHandler) Async(opts ...MultiAsyncOpt) *MultiHandler {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.config != nil {
+		return h
+	}
+
+	config := &MultiAsync{
+		QueueSize: 1024,
+	}
+	for _, opt := range opts {
+		opt(config)
+	}
+	config.normalize()
+
+	h.config = config
+	for _, handler := range h.Handlers {
+		h.startWorkerLocked(handler)
+	}
+	return h
+}
+
 // Len returns the number of handlers in the MultiHandler.
 // Useful for monitoring or debugging handler composition.
 //
@@ -40,12 +186,15 @@ func NewMultiHandler(h ...lx.Handler) *MultiHandler {
 //	multi.Append(h1, h2, h3)
 //	count := multi.Len() // Returns 3
 func (h *MultiHandler) Len() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
 	return len(h.Handlers)
 }
 
 // Append adds one or more handlers to the MultiHandler.
 // Handlers will receive log entries in the order they were appended.
-// This method modifies the MultiHandler in place.
+// This method modifies the MultiHandler in place. In async mode a worker is
+// started for each appended handler.
 //
 // Example:
 //
@@ -56,25 +205,298 @@ func (h *MultiHandler) Len() int {
 //	)
 //	// Now multi broadcasts to both stdout and file
 func (h *MultiHandler) Append(handlers ...lx.Handler) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
 	h.Handlers = append(h.Handlers, handlers...)
+	if h.config != nil && !h.closed {
+		for _, handler := range handlers {
+			h.startWorkerLocked(handler)
+		}
+	}
 }
 
 // Handle implements the Handler interface, calling Handle on each handler in sequence.
 // It collects any errors from handlers and combines them into a single error using errors.Join.
 // If no errors occur, it returns nil. Thread-safe if the underlying handlers are thread-safe.
+// In async mode the returned error reports entries dropped by full queues and any handler
+// errors raised by the workers since the previous call.
 // Example:
 //
 //	multi.Handle(&lx.Entry{Message: "test", Level: lx.LevelInfo}) // Calls Handle on all handlers
 func (h *MultiHandler) Handle(e *lx.Entry) error {
+	h.mu.RLock()
+	async := h.config != nil
+	handlers := h.Handlers // Append may grow the slice while handlers run
+	h.mu.RUnlock()
+	if async {
+		return h.handleAsync(e)
+	}
+
 	var errs []error // Collect errors from handlers
-	for i, handler := range h.Handlers {
+	for i, handler := range handlers {
 		// Process entry with each handler
 		if err := handler.Handle(e); err != nil {
-			// fmt.Fprintf(os.Stderr, "MultiHandler error for handler %d: %v\n", i, err)
 			// Wrap error with handler index for context
-			errs = append(errs, fmt.Errorf("handler %d: %writer", i, err))
+			errs = append(errs, fmt.Errorf("handler %d: %w", i, err))
 		}
 	}
 	// Combine errors into a single error, or return nil if no errors
 	return errors.Join(errs...)
 }
+
+// handleAsync enqueues a copy of the entry for every worker.
+func (h *MultiHandler) handleAsync(e *lx.Entry) error {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	if h.closed {
+		return ErrMultiClosed
+	}
+
+	// A single copy is shared by all workers; handlers must treat entries as read-only.
+	entryCopy := cloneEntry(e)
+
+	var errs []error
+	for _, w := range h.workers {
+		if !w.enqueue(multiItem{entry: entryCopy}) {
+			w.dropped.Add(1)
+			errs = append(errs, fmt.Errorf("handler %d: %w", w.index, ErrMultiDropped))
+		}
+		if err := w.takeErr(); err != nil {
+			errs = append(errs, fmt.Errorf("handler %d: %w", w.index, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Flush blocks until every entry queued before the call has been processed by its handler.
+// It is a no-op in synchronous mode or after Close.
+// Example:
+//
+//	multi.Flush() // Waits for all workers to catch up
+func (h *MultiHandler) Flush() {
+	h.mu.RLock()
+	if h.config == nil || h.closed {
+		h.mu.RUnlock()
+		return
+	}
+	workers := append([]*multiWorker(nil), h.workers...)
+	h.mu.RUnlock()
+
+	for _, w := range workers {
+		ack := make(chan struct{})
+		select {
+		case w.queue <- multiItem{ack: ack}:
+		case <-w.done:
+			continue
+		}
+		select {
+		case <-ack:
+		case <-w.exited:
+		}
+	}
+}
+
+// Stats returns a snapshot of the per-handler counters.
+// It returns nil in synchronous mode.
+// Example:
+//
+//	for _, s := range multi.Stats() {
+//	    fmt.Printf("handler %d: dropped=%d errors=%d\n", s.Index, s.Dropped, s.Errors)
+//	}
+func (h *MultiHandler) Stats() []MultiStats {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	if h.config == nil {
+		return nil
+	}
+	stats := make([]MultiStats, len(h.workers))
+	for i, w := range h.workers {
+		stats[i] = MultiStats{
+			Index:    w.index,
+			Handled:  w.handled.Load(),
+			Errors:   w.errors.Load(),
+			Dropped:  w.dropped.Load(),
+			Timeouts: w.timeouts.Load(),
+			Queued:   len(w.queue),
+		}
+	}
+	return stats
+}
+
+// Close drains any queued entries, stops the workers and closes every handler that
+// implements a Close() error method. Errors are combined using errors.Join.
+// It is safe to call Close more than once.
+// Example:
+//
+//	defer multi.Close()
+func (h *MultiHandler) Close() error {
+	h.mu.Lock()
+	if h.closed {
+		h.mu.Unlock()
+		return nil
+	}
+	h.closed = true
+	for _, w := range h.workers {
+		close(w.done)
+	}
+	handlers := h.Handlers
+	h.mu.Unlock()
+
+	h.wg.Wait()
+
+	var errs []error
+	for _, w := range h.workers {
+		if err := w.takeErr(); err != nil {
+			errs = append(errs, fmt.Errorf("handler %d: %w", w.index, err))
+		}
+	}
+	for i, handler := range handlers {
+		if c, ok := handler.(interface{ Close() error }); ok {
+			if err := c.Close(); err != nil {
+				errs = append(errs, fmt.Errorf("handler %d: %w", i, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// normalize replaces out-of-range values with their nearest valid value.
+func (c *MultiAsync) normalize() {
+	if c.QueueSize < 1 {
+		c.QueueSize = 1
+	}
+	if c.Timeout < 0 {
+		c.Timeout = 0
+	}
+	if c.HandleTimeout < 0 {
+		c.HandleTimeout = 0
+	}
+}
+
+// startWorkerLocked creates and starts a worker for handler (caller must hold h.mu).
+func (h *MultiHandler) startWorkerLocked(handler lx.Handler) {
+	index := len(h.workers)
+	config := *h.config
+	for _, opt := range h.config.children[index] {
+		opt(&config)
+	}
+	config.normalize()
+
+	w := &multiWorker{
+		index:         index,
+		handler:       handler,
+		queue:         make(chan multiItem, config.QueueSize),
+		timeout:       config.Timeout,
+		handleTimeout: config.HandleTimeout,
+		done:          make(chan struct{}),
+		exited:        make(chan struct{}),
+	}
+	h.workers = append(h.workers, w)
+	h.wg.Add(1)
+	go w.run(&h.wg)
+}
+
+// enqueue places an item on the worker's queue, waiting up to the worker's timeout for
+// space. Returns false if the item was not accepted.
+func (w *multiWorker) enqueue(item multiItem) bool {
+	select {
+	case w.queue <- item:
+		return true
+	default:
+	}
+	if w.timeout <= 0 {
+		return false
+	}
+
+	timer := time.NewTimer(w.timeout)
+	defer timer.Stop()
+	select {
+	case w.queue <- item:
+		return true
+	case <-timer.C:
+		return false
+	}
+}
+
+// run processes queued items until done is closed, then drains what is left.
+func (w *multiWorker) run(wg *sync.WaitGroup) {
+	defer wg.Done()
+	defer close(w.exited)
+	for {
+		select {
+		case item := <-w.queue:
+			w.process(item)
+		case <-w.done:
+			for {
+				select {
+				case item := <-w.queue:
+					w.process(item)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// process handles a single queued item, recording the outcome in the worker's counters.
+func (w *multiWorker) process(item multiItem) {
+	if item.ack != nil {
+		close(item.ack)
+		return
+	}
+	if w.handleTimeout <= 0 {
+		w.handled.Add(1)
+		w.record(w.handler.Handle(item.entry))
+		return
+	}
+
+	// An abandoned call is still running: skip the handler until it returns, rather
+	// than piling up goroutines on a stalled sink.
+	if w.pending != nil {
+		select {
+		case err := <-w.pending:
+			w.pending = nil
+			w.record(err)
+		default:
+			w.dropped.Add(1)
+			return
+		}
+	}
+
+	w.handled.Add(1)
+	result := make(chan error, 1)
+	go func() {
+		result <- w.handler.Handle(item.entry)
+	}()
+	timer := time.NewTimer(w.handleTimeout)
+	defer timer.Stop()
+	select {
+	case err := <-result:
+		w.record(err)
+	case <-timer.C:
+		w.timeouts.Add(1)
+		w.record(ErrMultiTimeout)
+		w.pending = result
+	}
+}
+
+// record counts a handler error, keeping it for the next Handle or Close to report.
+func (w *multiWorker) record(err error) {
+	if err == nil {
+		return
+	}
+	w.errors.Add(1)
+	w.errMu.Lock()
+	w.lastErr = err
+	w.errMu.Unlock()
+}
+
+// takeErr returns and clears the most recent unreported handler error.
+func (w *multiWorker) takeErr() error {
+	w.errMu.Lock()
+	defer w.errMu.Unlock()
+	err := w.lastErr
+	w.lastErr = nil
+	return err
+}
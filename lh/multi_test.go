@@ -0,0 +1,225 @@
+package lh
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/olekukonko/ll/lx"
+)
+
+// blockingHandler blocks every Handle call until release is closed.
+type blockingHandler struct {
+	release chan struct{}
+	count   atomic.Int32
+}
+
+func (b *blockingHandler) Handle(e *lx.Entry) error {
+	<-b.release
+	b.count.Add(1)
+	return nil
+}
+
+// failingHandler always returns err.
+type failingHandler struct {
+	err error
+}
+
+func (f *failingHandler) Handle(e *lx.Entry) error {
+	return f.err
+}
+
+// TestMultiHandler_SyncErrors verifies that synchronous errors are joined and wrapped.
+func TestMultiHandler_SyncErrors(t *testing.T) {
+	boom := errors.New("boom")
+	multi := NewMultiHandler(&countingHandler{}, &failingHandler{err: boom})
+
+	err := multi.Handle(&lx.Entry{Message: "test"})
+	if !errors.Is(err, boom) {
+		t.Fatalf("expected joined error to wrap boom, got %v", err)
+	}
+}
+
+// TestMultiHandler_SyncAppend verifies that Append is safe alongside synchronous Handle and Len.
+func TestMultiHandler_SyncAppend(t *testing.T) {
+	multi := NewMultiHandler()
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			multi.Append(&countingHandler{})
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			_ = multi.Handle(&lx.Entry{Message: "test"})
+			_ = multi.Len()
+		}
+	}()
+	wg.Wait()
+	if n := multi.Len(); n != 100 {
+		t.Errorf("expected 100 handlers, got %d", n)
+	}
+}
+
+// TestMultiHandler_AsyncIsolatesSlowHandler ensures a stalled handler does not block others.
+func TestMultiHandler_AsyncIsolatesSlowHandler(t *testing.T) {
+	slow := &blockingHandler{release: make(chan struct{})}
+	fast := &countingHandler{}
+	multi := NewMultiHandler(slow, fast).Async(WithAsyncQueueSize(2))
+
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < 10; i++ {
+			multi.Handle(&lx.Entry{Message: "test"})
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Handle blocked on a stalled handler")
+	}
+
+	stats := multi.Stats()
+	if len(stats) != 2 {
+		t.Fatalf("expected 2 stats entries, got %d", len(stats))
+	}
+	if stats[0].Dropped == 0 {
+		t.Errorf("expected drops on the stalled handler, got %+v", stats[0])
+	}
+
+	close(slow.release)
+	if err := multi.Close(); err != nil {
+		t.Fatalf("unexpected close error: %v", err)
+	}
+	// Entries may only be missing from the fast handler if its own queue overflowed.
+	if got, want := uint64(fast.count.Load()), 10-stats[1].Dropped; got < want {
+		t.Errorf("expected fast handler to see at least %d entries, got %d", want, got)
+	}
+}
+
+// TestMultiHandler_AsyncTimeout checks that Handle waits up to the timeout for queue space.
+func TestMultiHandler_AsyncTimeout(t *testing.T) {
+	slow := &blockingHandler{release: make(chan struct{})}
+	multi := NewMultiHandler(slow).Async(WithAsyncQueueSize(1), WithAsyncTimeout(20*time.Millisecond))
+
+	// First entry is picked up by the worker, second fills the queue.
+	multi.Handle(&lx.Entry{Message: "1"})
+	time.Sleep(10 * time.Millisecond)
+	multi.Handle(&lx.Entry{Message: "2"})
+
+	start := time.Now()
+	err := multi.Handle(&lx.Entry{Message: "3"})
+	if !errors.Is(err, ErrMultiDropped) {
+		t.Fatalf("expected ErrMultiDropped, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 20*time.Millisecond {
+		t.Errorf("expected Handle to wait for the timeout, returned after %v", elapsed)
+	}
+
+	close(slow.release)
+	multi.Close()
+	if got := slow.count.Load(); got != 2 {
+		t.Errorf("expected 2 handled entries, got %d", got)
+	}
+}
+
+// TestMultiHandler_AsyncHandleTimeout checks that a stalled child is abandoned after its
+// own handle timeout, counted in its stats, while the other child is unaffected.
+func TestMultiHandler_AsyncHandleTimeout(t *testing.T) {
+	stalled := &blockingHandler{release: make(chan struct{})}
+	fast := &countingHandler{}
+	multi := NewMultiHandler(fast, stalled).Async(
+		WithAsyncChild(1, WithAsyncHandleTimeout(10*time.Millisecond), WithAsyncQueueSize(8)),
+	)
+
+	multi.Handle(&lx.Entry{Message: "1"})
+	time.Sleep(30 * time.Millisecond) // The stalled call times out
+	if err := multi.Handle(&lx.Entry{Message: "2"}); !errors.Is(err, ErrMultiTimeout) {
+		t.Errorf("expected ErrMultiTimeout to be reported, got %v", err)
+	}
+	multi.Flush() // Must not wait for the stalled call
+
+	stats := multi.Stats()
+	if stats[1].Timeouts != 1 || stats[1].Dropped != 1 || stats[1].Handled != 1 {
+		t.Errorf("unexpected stalled child stats: %+v", stats[1])
+	}
+	if stats[0].Timeouts != 0 || fast.count.Load() != 2 {
+		t.Errorf("unexpected fast child stats: %+v", stats[0])
+	}
+
+	close(stalled.release)
+	multi.Close()
+}
+
+// TestMultiHandler_AsyncErrorsReported verifies worker errors surface via Handle and Stats.
+func TestMultiHandler_AsyncErrorsReported(t *testing.T) {
+	boom := errors.New("boom")
+	multi := NewMultiHandler(&failingHandler{err: boom}).Async()
+	defer multi.Close()
+
+	multi.Handle(&lx.Entry{Message: "test"})
+	multi.Flush()
+
+	if err := multi.Handle(&lx.Entry{Message: "test"}); !errors.Is(err, boom) {
+		t.Fatalf("expected previous worker error to be reported, got %v", err)
+	}
+	multi.Flush()
+	if s := multi.Stats()[0]; s.Errors != 2 || s.Handled != 2 {
+		t.Errorf("unexpected stats: %+v", s)
+	}
+}
+
+// TestMultiHandler_AsyncCopiesEntry ensures queued entries are isolated from caller reuse.
+func TestMultiHandler_AsyncCopiesEntry(t *testing.T) {
+	mem := NewMemoryHandler()
+	multi := NewMultiHandler(mem).Async()
+
+	e := &lx.Entry{Message: "original", Fields: lx.Fields{{Key: "k", Value: "v"}}}
+	multi.Handle(e)
+	e.Message = "mutated"
+	e.Fields[0].Value = "changed"
+	multi.Close()
+
+	entries := mem.Entries()
+	if len(entries) != 1 || entries[0].Message != "original" || entries[0].Fields[0].Value != "v" {
+		t.Fatalf("entry was not copied before enqueueing: %+v", entries)
+	}
+}
+
+// TestMultiHandler_AsyncAppendAndClose covers Append after Async and Handle after Close.
+func TestMultiHandler_AsyncAppendAndClose(t *testing.T) {
+	multi := NewMultiHandler().Async()
+	h := &countingHandler{}
+	multi.Append(h)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 25; j++ {
+				multi.Handle(&lx.Entry{Message: "test"})
+			}
+		}()
+	}
+	wg.Wait()
+	multi.Flush()
+
+	if got := h.count.Load(); got != 100 {
+		t.Errorf("expected 100 entries, got %d", got)
+	}
+	if err := multi.Close(); err != nil {
+		t.Fatalf("unexpected close error: %v", err)
+	}
+	if err := multi.Handle(&lx.Entry{}); !errors.Is(err, ErrMultiClosed) {
+		t.Errorf("expected ErrMultiClosed after Close, got %v", err)
+	}
+	multi.Flush() // must not block after Close
+}
@@ -1,10 +1,12 @@
 package lh
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"time"
 
+	"github.com/olekukonko/ll/lm"
 	"github.com/olekukonko/ll/lx"
 )
 
@@ -21,12 +23,12 @@ import (
 //
 //	base := lx.NewJSONHandler(os.Stdout)
 //	handler := lh.Pipe(base,
-//	    lh.NewDedup(2*time.Second),    // 1. Deduplicate first
-//	    lh.NewRateLimit(10, time.Second), // 2. Then rate limit
+//	    lh.PipeDedup(2*time.Second),                // 1. Deduplicate first
+//	    lh.PipeRate(lx.LevelInfo, 10, time.Second), // 2. Then rate limit
 //	)
-//	logger := lx.NewLogger(handler)
+//	logger := ll.New("app").Handler(handler)
 //
-// In this example, logs flow: Dedup → RateLimit → AddTimestamp → JSONHandler
+// In this example, logs flow: RateLimit → Dedup → JSONHandler
 func Pipe(h lx.Handler, wraps ...lx.Wrap) lx.Handler {
 	for _, w := range wraps {
 		if w != nil {
@@ -74,3 +76,140 @@ func PipeRotate(maxSizeBytes int64, src RotateSource) lx.Wrap {
 		return r
 	}
 }
+
+// PipeUse returns a wrapper that runs a logger middleware (any lx.Handler meant for
+// Logger.Use, such as lm.RateLimiter or lm.Sampling) in front of the handler.
+// Entries the middleware rejects with an error are dropped silently, mirroring how
+// the logger treats middleware errors.
+// Example:
+//
+//	h := lh.Pipe(victoriaHandler, lh.PipeUse(lm.NewRateLimiter(lx.LevelInfo, 100, time.Second)))
+func PipeUse(mw lx.Handler) lx.Wrap {
+	return func(next lx.Handler) lx.Handler {
+		return &pipeStage{next: next, stage: mw}
+	}
+}
+
+// PipeRate returns a wrapper that rate limits entries of the given level before they
+// reach the handler, allowing at most count entries per interval.
+func PipeRate(level lx.LevelType, count int, interval time.Duration) lx.Wrap {
+	return func(next lx.Handler) lx.Handler {
+		return &pipeStage{next: next, stage: lm.NewRateLimiter(level, count, interval)}
+	}
+}
+
+// PipeKeyedRate returns a wrapper that limits entries per key with rl. When a key is
+// allowed to log again after entries were suppressed, a "suppressed N similar entries"
+// summary carrying the key and count is forwarded ahead of the entry. Summaries still
+// pending are forwarded when the handler is closed.
+// Example:
+//
+//	limiter := lm.NewKeyedRateLimiter(lm.RateByField("user_id"), 5, time.Minute)
+//	h := lh.Pipe(victoriaHandler, lh.PipeKeyedRate(limiter))
+func PipeKeyedRate(rl *lm.KeyedRateLimiter) lx.Wrap {
+	return func(next lx.Handler) lx.Handler {
+		return &keyedRateStage{next: next, limiter: rl}
+	}
+}
+
+// PipeSample returns a wrapper that randomly samples entries of the given level,
+// letting through roughly rate (0.0 to 1.0) of them.
+func PipeSample(level lx.LevelType, rate float64) lx.Wrap {
+	return func(next lx.Handler) lx.Handler {
+		return &pipeStage{next: next, stage: lm.NewSampling(level, rate)}
+	}
+}
+
+// PipeFilter returns a wrapper that only forwards entries for which keep returns true.
+// Example:
+//
+//	warnOnly := lh.PipeFilter(func(e *lx.Entry) bool {
+//	    return e.Level == lx.LevelWarn || e.Level == lx.LevelError
+//	})
+//	h := lh.Pipe(victoriaHandler, warnOnly)
+func PipeFilter(keep func(e *lx.Entry) bool) lx.Wrap {
+	return func(next lx.Handler) lx.Handler {
+		return &pipeStage{next: next, stage: lx.HandlerFunc(func(e *lx.Entry) error {
+			if keep(e) {
+				return nil
+			}
+			return errPipeDropped
+		})}
+	}
+}
+
+// PipeMap returns a wrapper that lets fn modify each entry before it reaches the handler.
+// fn receives a copy of the entry, so changes are only seen by this handler and not by
+// siblings in a MultiHandler or Router.
+// Example:
+//
+//	h := lh.Pipe(fileHandler, lh.PipeMap(func(e *lx.Entry) {
+//	    e.Fields = append(e.Fields, lx.Field{Key: "sink", Value: "file"})
+//	}))
+func PipeMap(fn func(e *lx.Entry)) lx.Wrap {
+	return func(next lx.Handler) lx.Handler {
+		return lx.HandlerFunc(func(e *lx.Entry) error {
+			entryCopy := cloneEntry(e)
+			fn(entryCopy)
+			return next.Handle(entryCopy)
+		})
+	}
+}
+
+// errPipeDropped is returned by pipe stages to signal that an entry should not be forwarded.
+var errPipeDropped = errors.New("entry dropped by pipe stage")
+
+// pipeStage forwards an entry to next only if stage accepts it (returns nil).
+type pipeStage struct {
+	next  lx.Handler
+	stage lx.Handler
+}
+
+// Handle runs the stage and forwards accepted entries to the next handler.
+func (p *pipeStage) Handle(e *lx.Entry) error {
+	if err := p.stage.Handle(e); err != nil {
+		return nil // rejected by stage — drop silently
+	}
+	return p.next.Handle(e)
+}
+
+// Close closes the next handler if it implements a Close() error method.
+func (p *pipeStage) Close() error {
+	if c, ok := p.next.(interface{ Close() error }); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+// keyedRateStage forwards entries allowed by a keyed rate limiter, preceded by summaries
+// of the entries suppressed before them.
+type keyedRateStage struct {
+	next    lx.Handler
+	limiter *lm.KeyedRateLimiter
+}
+
+// Handle forwards the summary for the entry's key, if any, then the entry if allowed.
+func (k *keyedRateStage) Handle(e *lx.Entry) error {
+	summary, allowed := k.limiter.Take(e)
+	if summary != nil {
+		if err := k.next.Handle(summary); err != nil {
+			return err
+		}
+	}
+	if !allowed {
+		return nil
+	}
+	return k.next.Handle(e)
+}
+
+// Close forwards pending summaries, then closes the next handler if it implements a
+// Close() error method.
+func (k *keyedRateStage) Close() error {
+	for _, summary := range k.limiter.Drain() {
+		k.next.Handle(summary)
+	}
+	if c, ok := k.next.(interface{ Close() error }); ok {
+		return c.Close()
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package lh
+
+import (
+	"testing"
+	"time"
+
+	"github.com/olekukonko/ll/lm"
+	"github.com/olekukonko/ll/lx"
+)
+
+// TestPipeFilter verifies that rejected entries never reach the handler.
+func TestPipeFilter(t *testing.T) {
+	mem := NewMemoryHandler()
+	h := Pipe(mem, PipeFilter(func(e *lx.Entry) bool {
+		return e.Namespace == "app/audit"
+	}))
+
+	h.Handle(&lx.Entry{Namespace: "app/audit"})
+	h.Handle(&lx.Entry{Namespace: "app/api"})
+
+	if got := len(mem.Entries()); got != 1 {
+		t.Fatalf("expected 1 entry, got %d", got)
+	}
+}
+
+// TestPipeMap verifies that mapping only affects the wrapped handler.
+func TestPipeMap(t *testing.T) {
+	mapped := NewMemoryHandler()
+	plain := NewMemoryHandler()
+	multi := NewMultiHandler(
+		Pipe(mapped, PipeMap(func(e *lx.Entry) {
+			e.Message = "mapped"
+			e.Fields = append(e.Fields, lx.Field{Key: "sink", Value: "mapped"})
+		})),
+		plain,
+	)
+
+	multi.Handle(&lx.Entry{Message: "original"})
+
+	if got := mapped.Entries()[0]; got.Message != "mapped" || len(got.Fields) != 1 {
+		t.Errorf("expected mapped entry, got %+v", got)
+	}
+	if got := plain.Entries()[0]; got.Message != "original" || len(got.Fields) != 0 {
+		t.Errorf("sibling handler saw mapped entry: %+v", got)
+	}
+}
+
+// TestPipeRate verifies that a rate limit can be applied to a single sink.
+func TestPipeRate(t *testing.T) {
+	limited := NewMemoryHandler()
+	unlimited := NewMemoryHandler()
+	multi := NewMultiHandler(Pipe(limited, PipeRate(lx.LevelInfo, 2, time.Minute)), unlimited)
+
+	for i := 0; i < 5; i++ {
+		if err := multi.Handle(&lx.Entry{Level: lx.LevelInfo}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if got := len(limited.Entries()); got != 2 {
+		t.Errorf("expected 2 rate-limited entries, got %d", got)
+	}
+	if got := len(unlimited.Entries()); got != 5 {
+		t.Errorf("expected 5 unlimited entries, got %d", got)
+	}
+}
+
+// TestPipeKeyedRate verifies per-key limits and the suppression summaries.
+func TestPipeKeyedRate(t *testing.T) {
+	mem := NewMemoryHandler()
+	limiter := lm.NewKeyedRateLimiter(lm.RateByNamespace, 2, 50*time.Millisecond)
+	h := Pipe(mem, PipeKeyedRate(limiter))
+
+	for i := 0; i < 10; i++ {
+		h.Handle(&lx.Entry{Level: lx.LevelInfo, Namespace: "app/api"})
+		h.Handle(&lx.Entry{Level: lx.LevelInfo, Namespace: "app/db"})
+	}
+	if got := len(mem.Entries()); got != 4 {
+		t.Fatalf("expected 2 entries per key, got %d", got)
+	}
+
+	time.Sleep(30 * time.Millisecond) // Refills one token
+	h.Handle(&lx.Entry{Level: lx.LevelWarn, Namespace: "app/api", Message: "back"})
+	entries := mem.Entries()
+	if len(entries) != 6 {
+		t.Fatalf("expected summary and entry, got %d entries", len(entries))
+	}
+	summary := entries[4]
+	if summary.Message != "suppressed 8 similar entries" || summary.Namespace != "app/api" {
+		t.Errorf("unexpected summary: %+v", summary)
+	}
+	if v, _ := summary.Fields.Get(lm.RateKeyKey); v != "app/api" {
+		t.Errorf("expected summary key app/api, got %v", v)
+	}
+	if v, _ := summary.Fields.Get(lm.SuppressedKey); v != uint64(8) {
+		t.Errorf("expected 8 suppressed, got %v", v)
+	}
+	if entries[5].Message != "back" {
+		t.Errorf("expected the reopening entry after the summary, got %+v", entries[5])
+	}
+
+	// Suppressions for app/db are still pending and flushed on close.
+	h.(interface{ Close() error }).Close()
+	entries = mem.Entries()
+	if last := entries[len(entries)-1]; last.Message != "suppressed 8 similar entries" {
+		t.Errorf("expected pending summary on close, got %+v", last)
+	}
+}
+
+// TestPipeSample verifies the extreme sampling rates.
+func TestPipeSample(t *testing.T) {
+	none := NewMemoryHandler()
+	all := NewMemoryHandler()
+	dropAll := Pipe(none, PipeSample(lx.LevelDebug, 0))
+	keepAll := Pipe(all, PipeSample(lx.LevelDebug, 1))
+
+	for i := 0; i < 10; i++ {
+		dropAll.Handle(&lx.Entry{Level: lx.LevelDebug})
+		keepAll.Handle(&lx.Entry{Level: lx.LevelDebug})
+	}
+
+	if len(all.Entries()) != 10 {
+		t.Errorf("expected 10 entries at rate 1, got %d", len(all.Entries()))
+	}
+	if len(none.Entries()) > 1 {
+		t.Errorf("expected (almost) no entries at rate 0, got %d", len(none.Entries()))
+	}
+}
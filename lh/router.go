@@ -0,0 +1,259 @@
+package lh
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/olekukonko/ll/lx"
+)
+
+// RouteMode controls how many routes may receive a single entry.
+type RouteMode int
+
+const (
+	RouteFirst RouteMode = iota // Deliver to the first matching route only
+	RouteAll                    // Deliver to every matching route
+)
+
+// RouterOpt configures a Router.
+type RouterOpt func(*Router)
+
+// WithRouteMode sets whether entries stop at the first matching route or go to all of them.
+func WithRouteMode(mode RouteMode) RouterOpt {
+	return func(r *Router) {
+		r.mode = mode
+	}
+}
+
+// WithRouteDefault sets the handlers that receive entries no route matched.
+func WithRouteDefault(handlers ...lx.Handler) RouterOpt {
+	return func(r *Router) {
+		r.fallback = handlers
+	}
+}
+
+// Router dispatches each log entry to one or more handlers based on rules over
+// level, namespace, class and fields. Routes are evaluated in the order they were
+// added; entries matched by no route go to the default handlers, if any.
+// Thread-safe if the underlying handlers are thread-safe.
+type Router struct {
+	mu       sync.RWMutex
+	routes   []*Route
+	fallback []lx.Handler
+	mode     RouteMode
+}
+
+// Route is a single routing rule. All conditions added to a route must match
+// for an entry to be delivered to the route's handlers; a route without
+// conditions matches every entry.
+type Route struct {
+	router     *Router
+	handlers   []lx.Handler
+	conditions []func(*lx.Entry) bool
+}
+
+// NewRouter creates a new Router with the given options.
+// Example:
+//
+//	router := lh.NewRouter(lh.WithRouteDefault(console))
+//	router.Route(console).Class(lx.ClassDump, lx.ClassInspect, lx.ClassDbg)
+//	router.Route(auditFile).Namespace("app/audit")
+//	router.Route(victoria).MinLevel(lx.LevelWarn)
+//	logger := ll.New("app").Enable().Handler(router)
+func NewRouter(opts ...RouterOpt) *Router {
+	r := &Router{
+		mode: RouteFirst,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Route adds a new route delivering to the given handlers and returns it so
+// conditions can be attached with chained calls.
+// Example:
+//
+//	router.Route(auditFile).Namespace("app/audit", "billing/*/audit")
+func (r *Router) Route(handlers ...lx.Handler) *Route {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rt := &Route{router: r, handlers: handlers}
+	r.routes = append(r.routes, rt)
+	return rt
+}
+
+// Handle implements the lx.Handler interface, delivering the entry to the handlers
+// of the matching route(s), or to the default handlers when nothing matched.
+// Errors from all handlers are combined using errors.Join.
+// Example:
+//
+//	router.Handle(&lx.Entry{Message: "test", Level: lx.LevelWarn})
+func (r *Router) Handle(e *lx.Entry) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var errs []error
+	matched := false
+	for i, rt := range r.routes {
+		if !rt.matches(e) {
+			continue
+		}
+		matched = true
+		for j, handler := range rt.handlers {
+			if err := handler.Handle(e); err != nil {
+				errs = append(errs, fmt.Errorf("route %d handler %d: %w", i, j, err))
+			}
+		}
+		if r.mode == RouteFirst {
+			break
+		}
+	}
+
+	if !matched {
+		for j, handler := range r.fallback {
+			if err := handler.Handle(e); err != nil {
+				errs = append(errs, fmt.Errorf("default handler %d: %w", j, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Close closes every distinct handler referenced by the router that implements
+// a Close() error method. Errors are combined using errors.Join.
+func (r *Router) Close() error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	seen := make(map[lx.Handler]struct{})
+	var errs []error
+	closeHandler := func(h lx.Handler) {
+		// Non-comparable handlers (e.g., func types) cannot be map keys; close them as-is.
+		if reflect.TypeOf(h).Comparable() {
+			if _, ok := seen[h]; ok {
+				return
+			}
+			seen[h] = struct{}{}
+		}
+		if c, ok := h.(interface{ Close() error }); ok {
+			if err := c.Close(); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	for _, rt := range r.routes {
+		for _, h := range rt.handlers {
+			closeHandler(h)
+		}
+	}
+	for _, h := range r.fallback {
+		closeHandler(h)
+	}
+	return errors.Join(errs...)
+}
+
+// Levels restricts the route to entries with one of the given levels.
+func (rt *Route) Levels(levels ...lx.LevelType) *Route {
+	return rt.when(func(e *lx.Entry) bool {
+		for _, l := range levels {
+			if e.Level == l {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// MinLevel restricts the route to entries at least as severe as level,
+// using the order Debug < Info < Warn < Error < Fatal. Entries with
+// LevelNone or LevelUnknown never satisfy this condition.
+func (rt *Route) MinLevel(level lx.LevelType) *Route {
+	min := level.Severity()
+	return rt.when(func(e *lx.Entry) bool {
+		s := e.Level.Severity()
+		return s >= 0 && s >= min
+	})
+}
+
+// Namespace restricts the route to entries whose namespace matches any pattern.
+// A pattern containing glob metacharacters (*, ?, [) is matched with path.Match,
+// where * does not cross a "/" boundary. Any other pattern matches the namespace
+// itself and all of its children (e.g., "app/audit" matches "app/audit/users").
+func (rt *Route) Namespace(patterns ...string) *Route {
+	return rt.when(func(e *lx.Entry) bool {
+		for _, p := range patterns {
+			if matchNamespace(p, e.Namespace) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// Class restricts the route to entries of the given classes (e.g., lx.ClassDump, lx.ClassDbg).
+func (rt *Route) Class(classes ...lx.ClassType) *Route {
+	return rt.when(func(e *lx.Entry) bool {
+		for _, c := range classes {
+			if e.Class == c {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// Field restricts the route to entries carrying a field named key whose value
+// satisfies pred. A nil pred only requires the field to be present.
+func (rt *Route) Field(key string, pred func(value interface{}) bool) *Route {
+	return rt.when(func(e *lx.Entry) bool {
+		v, ok := e.Fields.Get(key)
+		if !ok {
+			return false
+		}
+		return pred == nil || pred(v)
+	})
+}
+
+// Match restricts the route with an arbitrary predicate over the entry.
+func (rt *Route) Match(pred func(e *lx.Entry) bool) *Route {
+	return rt.when(pred)
+}
+
+// when appends a condition to the route under the router's lock.
+func (rt *Route) when(cond func(*lx.Entry) bool) *Route {
+	rt.router.mu.Lock()
+	defer rt.router.mu.Unlock()
+	rt.conditions = append(rt.conditions, cond)
+	return rt
+}
+
+// matches reports whether every condition of the route holds for the entry.
+func (rt *Route) matches(e *lx.Entry) bool {
+	for _, cond := range rt.conditions {
+		if !cond(e) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchNamespace matches a namespace against a prefix or glob pattern.
+func matchNamespace(pattern, namespace string) bool {
+	if strings.ContainsAny(pattern, "*?[") {
+		ok, err := path.Match(pattern, namespace)
+		return err == nil && ok
+	}
+	if !strings.HasPrefix(namespace, pattern) {
+		return false
+	}
+	if len(namespace) == len(pattern) || pattern == "" {
+		return true
+	}
+	next := namespace[len(pattern) : len(pattern)+1]
+	return next == lx.Slash || next == lx.Dot
+}
@@ -0,0 +1,84 @@
+package lh
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/olekukonko/ll/lx"
+)
+
+// TestRouter_FirstMatch verifies that entries stop at the first matching route.
+func TestRouter_FirstMatch(t *testing.T) {
+	console := NewMemoryHandler()
+	audit := NewMemoryHandler()
+	remote := NewMemoryHandler()
+
+	router := NewRouter(WithRouteDefault(console))
+	router.Route(console).Class(lx.ClassDump, lx.ClassInspect, lx.ClassDbg)
+	router.Route(audit).Namespace("app/audit")
+	router.Route(remote).MinLevel(lx.LevelWarn)
+
+	router.Handle(&lx.Entry{Class: lx.ClassDbg, Level: lx.LevelError, Namespace: "app"})
+	router.Handle(&lx.Entry{Level: lx.LevelError, Namespace: "app/audit/users"})
+	router.Handle(&lx.Entry{Level: lx.LevelWarn, Namespace: "app/api"})
+	router.Handle(&lx.Entry{Level: lx.LevelInfo, Namespace: "app/api"})
+	router.Handle(&lx.Entry{Level: lx.LevelInfo, Namespace: "app/auditor"})
+
+	if got := len(console.Entries()); got != 3 {
+		t.Errorf("expected 3 console entries (dbg + 2 defaults), got %d", got)
+	}
+	if got := len(audit.Entries()); got != 1 {
+		t.Errorf("expected 1 audit entry, got %d", got)
+	}
+	if got := len(remote.Entries()); got != 1 {
+		t.Errorf("expected 1 remote entry, got %d", got)
+	}
+}
+
+// TestRouter_AllMatch verifies that all matching routes receive the entry.
+func TestRouter_AllMatch(t *testing.T) {
+	audit := NewMemoryHandler()
+	remote := NewMemoryHandler()
+	fallback := NewMemoryHandler()
+
+	router := NewRouter(WithRouteMode(RouteAll), WithRouteDefault(fallback))
+	router.Route(audit).Namespace("*/audit")
+	router.Route(remote).MinLevel(lx.LevelWarn)
+
+	router.Handle(&lx.Entry{Level: lx.LevelError, Namespace: "billing/audit"})
+	router.Handle(&lx.Entry{Level: lx.LevelDebug, Namespace: "billing"})
+
+	if len(audit.Entries()) != 1 || len(remote.Entries()) != 1 {
+		t.Errorf("expected entry on both routes, got audit=%d remote=%d", len(audit.Entries()), len(remote.Entries()))
+	}
+	if got := len(fallback.Entries()); got != 1 {
+		t.Errorf("expected 1 default entry, got %d", got)
+	}
+}
+
+// TestRouter_FieldPredicate covers field-based routing and combined conditions.
+func TestRouter_FieldPredicate(t *testing.T) {
+	tenant := NewMemoryHandler()
+	router := NewRouter()
+	router.Route(tenant).
+		Levels(lx.LevelInfo, lx.LevelWarn).
+		Field("tenant", func(v interface{}) bool { return v == "acme" })
+
+	router.Handle(&lx.Entry{Level: lx.LevelInfo, Fields: lx.Fields{{Key: "tenant", Value: "acme"}}})
+	router.Handle(&lx.Entry{Level: lx.LevelError, Fields: lx.Fields{{Key: "tenant", Value: "acme"}}})
+	router.Handle(&lx.Entry{Level: lx.LevelInfo, Fields: lx.Fields{{Key: "tenant", Value: "other"}}})
+	router.Handle(&lx.Entry{Level: lx.LevelInfo})
+
+	if got := len(tenant.Entries()); got != 1 {
+		t.Errorf("expected 1 tenant entry, got %d", got)
+	}
+}
+
+// TestRouter_Errors verifies that handler errors are joined.
+func TestRouter_Errors(t *testing.T) {
+	boom := errors.New("boom")
+	router := NewRouter(WithRouteDefault(&failingHandler{err: boom}))
+	if err := router.Handle(&lx.Entry{}); !errors.Is(err, boom) {
+		t.Fatalf("expected boom, got %v", err)
+	}
+}
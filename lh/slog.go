@@ -68,9 +68,14 @@ func (h *SlogHandler) Handle(e *lx.Entry) error {
 		record.AddAttrs(slog.String("stack", string(e.Stack))) // Add stack trace as string
 	}
 
+	// Add the attached error, if any, under the conventional "error" key
+	if e.Error != nil {
+		record.AddAttrs(slog.Any("error", e.Error))
+	}
+
 	// Add custom fields in order (preserving insertion order)
 	for _, pair := range e.Fields {
-		record.AddAttrs(slog.Any(pair.Key, pair.Value)) // Add each field as a key-value attribute
+		record.AddAttrs(slogAttr(pair)) // Add each field as a key-value attribute
 	}
 
 	// Handle the record with the underlying slog.Handler
@@ -97,3 +102,32 @@ func toSlogLevel(level lx.LevelType) slog.Level {
 		return slog.LevelInfo // Default for unknown levels
 	}
 }
+
+// slogAttr converts a field to a slog.Attr, keeping typed fields unboxed.
+func slogAttr(f lx.Field) slog.Attr {
+	switch f.Kind {
+	case lx.KindString:
+		return slog.String(f.Key, f.StringValue())
+	case lx.KindInt64:
+		return slog.Int64(f.Key, f.Int64Value())
+	case lx.KindUint64:
+		return slog.Uint64(f.Key, f.Uint64Value())
+	case lx.KindFloat64:
+		return slog.Float64(f.Key, f.Float64Value())
+	case lx.KindBool:
+		return slog.Bool(f.Key, f.BoolValue())
+	case lx.KindDuration:
+		return slog.Duration(f.Key, f.DurationValue())
+	case lx.KindTime:
+		return slog.Time(f.Key, f.TimeValue())
+	default:
+		if g, ok := f.GroupValue(); ok {
+			attrs := make([]slog.Attr, len(g))
+			for i, nested := range g {
+				attrs[i] = slogAttr(nested)
+			}
+			return slog.Attr{Key: f.Key, Value: slog.GroupValue(attrs...)}
+		}
+		return slog.Any(f.Key, lx.Resolve(f.Interface()))
+	}
+}
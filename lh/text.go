@@ -161,16 +161,30 @@ func (h *TextHandler) handleRegularOutput(e *lx.Entry) error {
 	buf.WriteString(lx.Space)
 	buf.WriteString(e.Message)
 
-	if len(e.Fields) > 0 {
+	// The attached error follows the fields, unless the message already is its text
+	var errText string
+	if e.Error != nil && e.Error.Error() != e.Message {
+		errText = e.Error.Error()
+	}
+	if len(e.Fields) > 0 || errText != "" {
 		buf.WriteString(lx.Space)
 		buf.WriteString(lx.LeftBracket)
-		for i, pair := range e.Fields {
-			if i > 0 {
+		n := 0
+		flattenFields(e.Fields, "", func(key string, pair lx.Field) {
+			if n > 0 {
 				buf.WriteString(lx.Space)
 			}
-			buf.WriteString(pair.Key)
+			n++
+			buf.WriteString(key)
 			buf.WriteString("=")
-			writeFieldValue(buf, pair.Value)
+			writeField(buf, pair)
+		})
+		if errText != "" {
+			if n > 0 {
+				buf.WriteString(lx.Space)
+			}
+			buf.WriteString("error=")
+			buf.WriteString(errText)
 		}
 		buf.WriteString(lx.RightBracket)
 	}
@@ -3,6 +3,7 @@ package ll
 import (
 	"encoding/binary"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"math"
@@ -65,6 +66,10 @@ type Logger struct {
 	fatalExits      bool
 	fatalStack      bool
 	labels          atomic.Pointer[[]string]
+	timings         *Timings   // Aggregates timed operations, if attached
+	spanStarts      bool       // Log an entry when a span starts, not only when it ends
+	recorder        lx.Handler // Receives entries below the level, e.g. a flight recorder
+	atomicRecording int32      // 1 if recorder is set, for lock-free checks
 }
 
 // New creates a new Logger with the given namespace and optional configurations.
@@ -216,6 +221,10 @@ func (l *Logger) Clone() *Logger {
 		stackBufferSize: l.stackBufferSize, // Copy stack trace buffer size
 		separator:       l.separator,       // Default separator ("/")
 		suspend:         l.suspend,
+		timings:         l.timings,
+		spanStarts:      l.spanStarts,
+		recorder:        l.recorder,
+		atomicRecording: l.atomicRecording,
 	}
 }
 
@@ -248,6 +257,10 @@ func (l *Logger) Context(fields map[string]interface{}) *Logger {
 		suspend:         l.suspend,
 		fatalExits:      l.fatalExits,
 		fatalStack:      l.fatalStack,
+		timings:         l.timings,
+		spanStarts:      l.spanStarts,
+		recorder:        l.recorder,
+		atomicRecording: l.atomicRecording,
 	}
 	// Copy parent's context fields (in order)
 	newLogger.context = append(newLogger.context, l.context...)
@@ -269,7 +282,7 @@ func (l *Logger) Debug(args ...any) {
 		return
 	}
 	// Skip logging if Debug level is not enabled
-	if !l.shouldLog(lx.LevelDebug) {
+	if !l.shouldHandle(lx.LevelDebug) {
 		return
 	}
 	l.log(lx.LevelDebug, lx.ClassText, cat.Space(args...), nil, false)
@@ -401,7 +414,7 @@ func (l *Logger) output(skip int, values ...interface{}) {
 		return
 	}
 
-	if !l.shouldLog(lx.LevelInfo) {
+	if !l.shouldHandle(lx.LevelInfo) {
 		return
 	}
 	_, file, line, ok := runtime.Caller(skip)
@@ -471,33 +484,26 @@ func (l *Logger) Enabled() bool {
 	return l.enabled.Load() == lx.Active
 }
 
-// Err adds one or more errors to the loggerâ   s context and logs them at Error level.
-// Non-nil errors are stored in the "error" context field (single error or slice) and
-// logged as a concatenated string (e.g., "failed 1; failed 2"). It is thread-safe and
-// returns the logger for chaining.
+// Err logs one or more errors at Error level. Non-nil errors are logged as a
+// concatenated message (e.g., "failed 1; failed 2") and attached to the entry as
+// Entry.Error (joined with errors.Join if there are several), which handlers render
+// with its cause chain. The logger's context is not modified. It is thread-safe.
 // Example:
 //
 //	logger := New("app").Enable()
 //	err1 := errors.New("failed 1")
 //	err2 := errors.New("failed 2")
-//	logger.Err(err1, err2).Info("Error occurred")
+//	logger.Err(err1, err2)
 //	// Output: [app] ERROR: failed 1; failed 2
-//	//         [app] INFO: Error occurred [error=[failed 1 failed 2]]
 func (l *Logger) Err(errs ...error) {
 	if l.suspend.Load() {
 		return
 	}
 
 	// Skip logging if Error level is not enabled
-	if !l.shouldLog(lx.LevelError) {
+	if !l.shouldHandle(lx.LevelError) {
 		return
 	}
-	l.mu.Lock()
-	defer l.mu.Unlock()
-	// Initialize context slice if nil
-	if l.context == nil {
-		l.context = make(lx.Fields, 0, 4)
-	}
 	// Collect non-nil errors and build log message
 	var nonNilErrors []error
 	var builder strings.Builder
@@ -513,18 +519,19 @@ func (l *Logger) Err(errs ...error) {
 		}
 	}
 	if count > 0 {
-		if count == 1 {
-			// Store single error directly
-			l.context = append(l.context, lx.Field{Key: "error", Value: nonNilErrors[0]})
-		} else {
-			// Store slice of errors
-			l.context = append(l.context, lx.Field{Key: "error", Value: nonNilErrors})
-		}
-		// Log concatenated error messages
-		l.log(lx.LevelError, lx.ClassText, builder.String(), nil, false)
+		// Log concatenated error messages, attaching the errors to the entry
+		l.logErr(lx.LevelError, lx.ClassText, builder.String(), nil, joinErrors(nonNilErrors), false)
 	}
 }
 
+// joinErrors returns the single error in errs, or errors.Join of all of them.
+func joinErrors(errs []error) error {
+	if len(errs) == 1 {
+		return errs[0]
+	}
+	return errors.Join(errs...)
+}
+
 // Error logs a message at Error level, formatting it and delegating to the internal
 // log method. It is thread-safe.
 // Example:
@@ -536,7 +543,7 @@ func (l *Logger) Error(args ...any) {
 		return
 	}
 	// Skip logging if Error level is not enabled
-	if !l.shouldLog(lx.LevelError) {
+	if !l.shouldHandle(lx.LevelError) {
 		return
 	}
 	l.log(lx.LevelError, lx.ClassText, cat.Space(args...), nil, false)
@@ -565,7 +572,7 @@ func (l *Logger) Fatal(args ...any) {
 	if l.suspend.Load() {
 		return
 	}
-	if !l.shouldLog(lx.LevelError) {
+	if !l.shouldHandle(lx.LevelError) {
 		os.Exit(1)
 	}
 	l.log(lx.LevelFatal, lx.ClassText, cat.Space(args...), nil, l.fatalStack)
@@ -672,7 +679,7 @@ func (l *Logger) GetContext() map[string]interface{} {
 	// Convert slice to map for backward compatibility
 	contextMap := make(map[string]interface{}, len(l.context))
 	for _, pair := range l.context {
-		contextMap[pair.Key] = pair.Value
+		contextMap[pair.Key] = pair.Interface()
 	}
 	return contextMap
 }
@@ -751,6 +758,48 @@ func (l *Logger) Handler(handler lx.Handler) *Logger {
 	return l
 }
 
+// Timings attaches a timing aggregator, so durations logged through Since and Measure
+// are added to its histograms instead of being logged one entry per call (unless it was
+// created with WithTimingEntries). Child loggers created afterwards share it; pass nil
+// to detach. It is thread-safe using a write lock and returns the logger for chaining.
+// Example:
+//
+//	timings := ll.NewTimings(logger, time.Minute)
+//	logger.Timings(timings)
+//	logger.Since(start).Info("query") // Summarized every minute
+func (l *Logger) Timings(t *Timings) *Logger {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.timings = t
+	return l
+}
+
+// Recorder attaches a handler that receives the entries the level filter drops, such as
+// Debug entries on a logger at Info level, so a flight recorder can keep them as context
+// without them reaching the logger's handler. Entries at or above the level still go to
+// the handler only. Recorded entries pass through the middleware, so redaction applies
+// to them, except gates (see lx.Gate) such as rate limits, sampling and metrics, which
+// only see logged entries; they skip timings, and CanLog still reports the level filter.
+// Child loggers created afterwards share it; pass nil to detach. It is thread-safe using
+// a write lock and returns the logger for chaining.
+// Example:
+//
+//	flight := lh.NewFlightRecorder()
+//	logger := ll.New("app").Enable().Level(lx.LevelInfo).Handler(lh.Pipe(sink, flight.Wrap)).Recorder(flight)
+//	logger.Debug("cache miss")   // Recorded only
+//	logger.Error("query failed") // Preceded by the recorded context
+func (l *Logger) Recorder(recorder lx.Handler) *Logger {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.recorder = recorder
+	recording := int32(0)
+	if recorder != nil {
+		recording = 1
+	}
+	atomic.StoreInt32(&l.atomicRecording, recording)
+	return l
+}
+
 // Indent sets the indentation level for log messages, adding two spaces per level. It is
 // thread-safe using a write lock and returns the logger for chaining.
 // Example:
@@ -774,7 +823,7 @@ func (l *Logger) Info(args ...any) {
 	if l.suspend.Load() {
 		return
 	}
-	if !l.shouldLog(lx.LevelInfo) {
+	if !l.shouldHandle(lx.LevelInfo) {
 		return
 	}
 	l.log(lx.LevelInfo, lx.ClassText, cat.Space(args...), nil, false)
@@ -883,7 +932,7 @@ func (l *Logger) mark(skip int, names ...string) {
 		return
 	}
 	// Skip logging if Info level is not enabled
-	if !l.shouldLog(lx.LevelInfo) {
+	if !l.shouldHandle(lx.LevelInfo) {
 		return
 	}
 	// Get caller information (file, line)
@@ -941,6 +990,10 @@ func (l *Logger) Namespace(name string) *Logger {
 		stackBufferSize: l.stackBufferSize,
 		separator:       l.separator,
 		suspend:         l.suspend,
+		timings:         l.timings,
+		spanStarts:      l.spanStarts,
+		recorder:        l.recorder,
+		atomicRecording: l.atomicRecording,
 	}
 }
 
@@ -1019,7 +1072,7 @@ func (l *Logger) Panic(args ...any) {
 		panic(msg)
 	}
 	// Panic immediately if Error level is not enabled
-	if !l.shouldLog(lx.LevelError) {
+	if !l.shouldHandle(lx.LevelError) {
 		panic(msg)
 	}
 	l.log(lx.LevelFatal, lx.ClassText, msg, nil, true)
@@ -1060,7 +1113,7 @@ func (l *Logger) Print(args ...any) {
 		return
 	}
 	// Skip logging if Info level is not enabled
-	if !l.shouldLog(lx.LevelInfo) {
+	if !l.shouldHandle(lx.LevelInfo) {
 		return
 	}
 	l.log(lx.LevelNone, lx.ClassRaw, cat.Space(args...), nil, false)
@@ -1077,7 +1130,7 @@ func (l *Logger) Println(args ...any) {
 		return
 	}
 	// Skip logging if Info level is not enabled
-	if !l.shouldLog(lx.LevelInfo) {
+	if !l.shouldHandle(lx.LevelInfo) {
 		return
 	}
 	l.log(lx.LevelNone, lx.ClassRaw, cat.SuffixWith(lx.Space, lx.Newline, args...), nil, false)
@@ -1274,7 +1327,7 @@ func (l *Logger) Warn(args ...any) {
 		return
 	}
 	// Skip logging if Warn level is not enabled
-	if !l.shouldLog(lx.LevelWarn) {
+	if !l.shouldHandle(lx.LevelWarn) {
 		return
 	}
 	l.log(lx.LevelWarn, lx.ClassText, cat.Space(args...), nil, false)
@@ -1325,9 +1378,20 @@ func (l *Logger) joinPath(base, relative string) string {
 // error stops the log. It is thread-safe with read/write locks for configuration and stack
 // trace buffer.
 func (l *Logger) log(level lx.LevelType, class lx.ClassType, msg string, fields lx.Fields, withStack bool) {
-	// Skip logging if level is not enabled (fast path)
+	l.logErr(level, class, msg, fields, nil, withStack)
+}
+
+// logErr is log with an error attached to the entry as Entry.Error, which handlers
+// render with its full cause chain.
+func (l *Logger) logErr(level lx.LevelType, class lx.ClassType, msg string, fields lx.Fields, err error, withStack bool) {
+	// Skip logging if level is not enabled (fast path). Entries below the level go to
+	// the recorder, if any.
+	recorded := false
 	if !l.shouldLog(level) {
-		return
+		if !l.shouldRecord(level) {
+			return
+		}
+		recorded = true
 	}
 
 	var stack []byte
@@ -1351,6 +1415,9 @@ func (l *Logger) log(level lx.LevelType, class lx.ClassType, msg string, fields
 	// Read-only config snapshot (minimal lock scope)
 	l.mu.RLock()
 	handler := l.handler
+	if recorded {
+		handler = l.recorder
+	}
 	prefix := l.prefix
 	indent := l.indent
 	context := l.context
@@ -1399,6 +1466,7 @@ func (l *Logger) log(level lx.LevelType, class lx.ClassType, msg string, fields
 		// Reset slices to zero length but keep capacity for pool reuse
 		entry.Fields = entry.Fields[:0]
 		entry.Stack = entry.Stack[:0]
+		entry.Error = nil
 		entryPool.Put(entry)
 	}()
 
@@ -1410,19 +1478,34 @@ func (l *Logger) log(level lx.LevelType, class lx.ClassType, msg string, fields
 	entry.Style = style
 	entry.Class = class
 	entry.Stack = stack
-	entry.Error = nil
+	entry.Error = err
 	entry.Id = 0
 
-	// Apply middleware, stopping if any returns an error
+	// Apply middleware, stopping if any returns an error. Recorded entries were not
+	// logged, so gates (rate limits, sampling, metrics) skip them, but the others,
+	// such as redaction, apply before the recorder keeps them.
 	for _, mw := range middleware {
+		if _, ok := mw.fn.(lx.Gate); ok && recorded {
+			continue
+		}
 		if err := mw.fn.Handle(entry); err != nil {
 			// Defer handles pool return
 			return
 		}
 	}
 
+	// Lazy values of recorded entries are left for the recorder's handler to resolve
+	if recorded {
+		if handler != nil {
+			_ = handler.Handle(entry)
+		}
+		return
+	}
+
 	// Pass to handler if set
 	if handler != nil {
+		// Lazy values are computed once, only for entries that made it this far
+		entry.Fields = entry.Fields.Resolve()
 		_ = handler.Handle(entry)
 		l.entries.Add(1)
 	}
@@ -1445,9 +1528,29 @@ func (l *Logger) shouldLog(level lx.LevelType) bool {
 	}
 
 	// Atomic fast path: read level without lock
-	if level > lx.LevelType(atomic.LoadInt32(&l.atomicLevel)) {
+	if belowLevel(level, lx.LevelType(atomic.LoadInt32(&l.atomicLevel))) {
 		return false
 	}
+	return l.admits()
+}
+
+// shouldRecord reports whether an entry at level, dropped by the level filter, goes to
+// the recorder attached with Recorder. The other rules of shouldLog still apply.
+func (l *Logger) shouldRecord(level lx.LevelType) bool {
+	if atomic.LoadInt32(&l.atomicRecording) == 0 || !Active() {
+		return false
+	}
+	return belowLevel(level, lx.LevelType(atomic.LoadInt32(&l.atomicLevel))) && l.admits()
+}
+
+// shouldHandle reports whether an entry at level is either logged or recorded, for the
+// fast paths of the logging methods. CanLog and timings use shouldLog alone.
+func (l *Logger) shouldHandle(level lx.LevelType) bool {
+	return l.shouldLog(level) || l.shouldRecord(level)
+}
+
+// admits applies the enablement and namespace rules shared by shouldLog and shouldRecord.
+func (l *Logger) admits() bool {
 	// Check namespace rules if path is set (minimal lock scope)
 	if l.currentPath != "" {
 		separator := l.separator
@@ -1464,3 +1567,21 @@ func (l *Logger) shouldLog(level lx.LevelType) bool {
 	}
 	return l.enabled.Load() == lx.Active
 }
+
+// belowLevel reports whether an entry at level is less severe than the minimum level min,
+// using the order Debug < Info < Warn < Error < Fatal. Entries without a severity
+// (LevelNone, LevelUnknown) are never below it; a minimum of LevelNone admits none of
+// the others, and LevelUnknown admits all of them.
+func belowLevel(level, min lx.LevelType) bool {
+	rank := level.Severity()
+	if rank < 0 {
+		return false
+	}
+	switch min {
+	case lx.LevelNone:
+		return true
+	case lx.LevelUnknown:
+		return false
+	}
+	return rank < min.Severity()
+}
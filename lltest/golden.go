@@ -0,0 +1,166 @@
+package lltest
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/olekukonko/ll/lh"
+	"github.com/olekukonko/ll/lx"
+)
+
+// Update makes Snapshot and AssertGolden write golden files with the current output
+// instead of comparing. It is also enabled by setting the LLTEST_UPDATE environment
+// variable to a true value, or by a boolean -update flag that the test binary defines
+// itself; lltest does not register any flags. Bind it to a flag in the test package:
+//
+//	func init() { flag.BoolVar(&lltest.Update, "update", false, "update golden files") }
+var Update bool
+
+// updateEnv is the environment variable that enables Update.
+const updateEnv = "LLTEST_UPDATE"
+
+// updating reports whether golden files should be written.
+func updating() bool {
+	if Update {
+		return true
+	}
+	if v, err := strconv.ParseBool(os.Getenv(updateEnv)); err == nil && v {
+		return true
+	}
+	f := flag.Lookup("update")
+	if f == nil {
+		return false
+	}
+	getter, ok := f.Value.(flag.Getter)
+	if !ok {
+		return false
+	}
+	update, _ := getter.Get().(bool)
+	return update
+}
+
+// Format selects the handler that renders entries for a snapshot.
+type Format int
+
+const (
+	FormatText  Format = iota // lh.TextHandler
+	FormatJSON                // lh.JSONHandler
+	FormatColor               // lh.ColorizedHandler, with ANSI escapes stripped
+)
+
+// Scrubber normalizes volatile parts of rendered output so snapshots are stable.
+type Scrubber func([]byte) []byte
+
+// ScrubRegexp returns a scrubber replacing matches of pattern with repl, which may
+// refer to submatches as in regexp.Regexp.ReplaceAll.
+func ScrubRegexp(pattern, repl string) Scrubber {
+	re := regexp.MustCompile(pattern)
+	return func(b []byte) []byte {
+		return re.ReplaceAll(b, []byte(repl))
+	}
+}
+
+var (
+	// ScrubTimestamps replaces RFC 3339 and time.Time.String timestamps, with or without
+	// fractional seconds and zone, and time.Stamp-style clock times with <TIME>.
+	ScrubTimestamps = ScrubRegexp(
+		`\d{4}-\d{2}-\d{2}[T ]\d{2}:\d{2}:\d{2}(\.\d+)?( ?(Z|[+-]\d{2}:?\d{2}))?( [A-Z]{3,5}\b)?( m=[+-]\d+\.\d+)?|`+
+			`\b\d{2}:\d{2}:\d{2}(\.\d+)?\b`,
+		"<TIME>")
+
+	// ScrubDurations replaces Go duration strings such as "1.5ms" or "2m3.5s" with <DURATION>.
+	ScrubDurations = ScrubRegexp(`\b\d+(\.\d+)?(ns|µs|us|ms|s|m|h)(\d+(\.\d+)?(ns|µs|us|ms|s|m|h))*\b`, "<DURATION>")
+
+	// ScrubPointers replaces hexadecimal addresses such as 0xc000012345 with <PTR>.
+	ScrubPointers = ScrubRegexp(`0x[0-9a-fA-F]{6,}`, "<PTR>")
+
+	// ScrubGoroutines replaces goroutine IDs in stack traces with <N>.
+	ScrubGoroutines = ScrubRegexp(`goroutine \d+`, "goroutine <N>")
+
+	// ScrubStackAddresses replaces directories, line numbers and program counter offsets
+	// in stack traces, such as "/src/app/main.go:42 +0x1d", with "main.go:<LINE>".
+	ScrubStackAddresses = ScrubRegexp(`(?:[^\s:]*/)?([^/\s]+\.go):\d+(?: \+0x[0-9a-f]+)?`, "$1:<LINE>")
+)
+
+// DefaultScrubbers returns the built-in scrubbers, in the order snapshots apply them.
+// Timestamps are scrubbed before durations, so clock times are not mistaken for them.
+func DefaultScrubbers() []Scrubber {
+	return []Scrubber{ScrubTimestamps, ScrubStackAddresses, ScrubGoroutines, ScrubPointers, ScrubDurations}
+}
+
+// ansiEscape matches ANSI escape sequences.
+var ansiEscape = regexp.MustCompile(`\x1b\[[0-9;]*[A-Za-z]`)
+
+// Render formats entries as the handler selected by format would write them.
+func Render(format Format, entries []*lx.Entry) ([]byte, error) {
+	var buf bytes.Buffer
+	var handler lx.Handler
+	switch format {
+	case FormatJSON:
+		handler = lh.NewJSONHandler(&buf)
+	case FormatColor:
+		handler = lh.NewColorizedHandler(&buf)
+	default:
+		handler = lh.NewTextHandler(&buf)
+	}
+	for _, e := range entries {
+		if err := handler.Handle(e); err != nil {
+			return nil, err
+		}
+	}
+	if format == FormatColor {
+		return ansiEscape.ReplaceAll(buf.Bytes(), nil), nil
+	}
+	return buf.Bytes(), nil
+}
+
+// Snapshot renders the recorded entries in format, scrubs them with DefaultScrubbers
+// followed by extra, and compares the result with testdata/<name>.golden, relative to
+// the test's package directory. Set Update, or LLTEST_UPDATE=1, to write the golden file.
+// Example:
+//
+//	logger, rec := lltest.New(t)
+//	logger.Fields("user", "alice").Info("login")
+//	rec.Snapshot(t, "login", lltest.FormatJSON)
+func (r *Recorder) Snapshot(t testing.TB, name string, format Format, extra ...Scrubber) {
+	t.Helper()
+	out, err := Render(format, r.Entries())
+	if err != nil {
+		t.Fatalf("rendering snapshot %s: %v", name, err)
+		return
+	}
+	for _, scrub := range append(DefaultScrubbers(), extra...) {
+		out = scrub(out)
+	}
+	AssertGolden(t, name, out)
+}
+
+// AssertGolden compares got with testdata/<name>.golden, reporting a test error showing
+// both on mismatch. With Update, it writes got to the file instead.
+func AssertGolden(t testing.TB, name string, got []byte) {
+	t.Helper()
+	path := filepath.Join("testdata", name+".golden")
+	if updating() {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("creating golden directory: %v", err)
+			return
+		}
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatalf("writing golden file: %v", err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading golden file (set LLTEST_UPDATE=1 to create it): %v", err)
+		return
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("output does not match %s (set LLTEST_UPDATE=1 to accept it)\n--- got:\n%s\n--- want:\n%s", path, got, want)
+	}
+}
@@ -0,0 +1,275 @@
+// Package lltest provides helpers for asserting on log output in unit tests.
+//
+// New returns a logger whose entries are recorded in memory, and shown through t.Log
+// if the test fails:
+//
+//	func TestCheckout(t *testing.T) {
+//	    logger, rec := lltest.New(t)
+//	    checkout(logger)
+//	    rec.AssertLogged(t, lx.LevelInfo, "order placed", lltest.Field("items", 3))
+//	    rec.AssertNotLogged(t, lx.LevelError, "")
+//	}
+package lltest
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/olekukonko/ll"
+	"github.com/olekukonko/ll/lh"
+	"github.com/olekukonko/ll/lx"
+)
+
+// AnyLevel matches entries of every level in Recorder queries and assertions.
+const AnyLevel lx.LevelType = -1
+
+// Recorder records log entries in memory and provides assertions over them.
+// It is an lh.MemoryHandler, so Entries, Reset and Dump are available too.
+type Recorder struct {
+	*lh.MemoryHandler
+}
+
+// NewRecorder creates an empty Recorder, for use as a logger's handler.
+func NewRecorder() *Recorder {
+	return &Recorder{MemoryHandler: lh.NewMemoryHandler()}
+}
+
+// New creates an enabled logger that logs every level to a new Recorder, and to t
+// through a TBHandler, so the output is shown if the test fails.
+// Example:
+//
+//	logger, rec := lltest.New(t)
+//	logger.Fields("port", 8080).Info("listening")
+//	rec.AssertLogged(t, lx.LevelInfo, "listening", lltest.Field("port", 8080))
+func New(t testing.TB) (*ll.Logger, *Recorder) {
+	rec := NewRecorder()
+	logger := ll.New("test").Enable().Level(lx.LevelDebug).Handler(lh.NewMultiHandler(rec, NewTBHandler(t)))
+	return logger, rec
+}
+
+// Find returns the recorded entries at level (or AnyLevel) whose message contains msg
+// and which satisfy every matcher. An empty msg matches every message.
+func (r *Recorder) Find(level lx.LevelType, msg string, matchers ...Matcher) []*lx.Entry {
+	var found []*lx.Entry
+	for _, e := range r.Entries() {
+		if matches(e, level, msg, matchers) {
+			found = append(found, e)
+		}
+	}
+	return found
+}
+
+// Count returns the number of entries Find would return.
+func (r *Recorder) Count(level lx.LevelType, msg string, matchers ...Matcher) int {
+	return len(r.Find(level, msg, matchers...))
+}
+
+// AssertLogged reports a test error unless an entry at level (or AnyLevel) whose message
+// contains msg and which satisfies every matcher was recorded. It returns whether one was.
+func (r *Recorder) AssertLogged(t testing.TB, level lx.LevelType, msg string, matchers ...Matcher) bool {
+	t.Helper()
+	if r.Count(level, msg, matchers...) > 0 {
+		return true
+	}
+	t.Errorf("expected an entry %s; recorded:\n%s", describe(level, msg, matchers), r.dump())
+	return false
+}
+
+// AssertNotLogged reports a test error if an entry at level (or AnyLevel) whose message
+// contains msg and which satisfies every matcher was recorded. It returns whether none was.
+func (r *Recorder) AssertNotLogged(t testing.TB, level lx.LevelType, msg string, matchers ...Matcher) bool {
+	t.Helper()
+	if r.Count(level, msg, matchers...) == 0 {
+		return true
+	}
+	t.Errorf("expected no entry %s; recorded:\n%s", describe(level, msg, matchers), r.dump())
+	return false
+}
+
+// RequireCount stops the test unless exactly want entries at level (or AnyLevel) whose
+// message contains msg and which satisfy every matcher were recorded.
+func (r *Recorder) RequireCount(t testing.TB, want int, level lx.LevelType, msg string, matchers ...Matcher) {
+	t.Helper()
+	if got := r.Count(level, msg, matchers...); got != want {
+		t.Fatalf("expected %d entries %s, got %d; recorded:\n%s", want, describe(level, msg, matchers), got, r.dump())
+	}
+}
+
+// dump renders the recorded entries as text for failure messages.
+func (r *Recorder) dump() string {
+	var buf bytes.Buffer
+	r.Dump(&buf)
+	if buf.Len() == 0 {
+		return "  (no entries)"
+	}
+	return buf.String()
+}
+
+// Matcher is a condition on a recorded entry, built by Field, HasField, NoField,
+// FieldContains, FieldFunc or Err.
+type Matcher struct {
+	desc  string
+	match func(e *lx.Entry) bool
+}
+
+// String describes the condition.
+func (m Matcher) String() string {
+	return m.desc
+}
+
+// Field matches entries with a field named key equal to want. Numbers compare by value
+// regardless of type, so Field("count", 3) matches a field logged as int64(3).
+func Field(key string, want any) Matcher {
+	return FieldFunc(key, func(v any) bool { return valuesEqual(v, want) }).describe(fmt.Sprintf("%s=%v", key, want))
+}
+
+// HasField matches entries with a field named key, whatever its value.
+func HasField(key string) Matcher {
+	return FieldFunc(key, nil).describe(key + " present")
+}
+
+// NoField matches entries without a field named key.
+func NoField(key string) Matcher {
+	return Matcher{
+		desc: key + " absent",
+		match: func(e *lx.Entry) bool {
+			_, ok := e.Fields.Get(key)
+			return !ok
+		},
+	}
+}
+
+// FieldContains matches entries with a field named key whose formatted value contains substr.
+func FieldContains(key, substr string) Matcher {
+	return FieldFunc(key, func(v any) bool {
+		return strings.Contains(fmt.Sprint(v), substr)
+	}).describe(fmt.Sprintf("%s containing %q", key, substr))
+}
+
+// FieldFunc matches entries with a field named key whose value satisfies pred.
+// A nil pred only requires the field to be present.
+func FieldFunc(key string, pred func(value any) bool) Matcher {
+	return Matcher{
+		desc: key + " matching predicate",
+		match: func(e *lx.Entry) bool {
+			v, ok := e.Fields.Get(key)
+			return ok && (pred == nil || pred(v))
+		},
+	}
+}
+
+// Err matches entries carrying an error whose message contains substr.
+func Err(substr string) Matcher {
+	return Matcher{
+		desc: fmt.Sprintf("error containing %q", substr),
+		match: func(e *lx.Entry) bool {
+			return e.Error != nil && strings.Contains(e.Error.Error(), substr)
+		},
+	}
+}
+
+// describe returns m with its description replaced.
+func (m Matcher) describe(desc string) Matcher {
+	m.desc = desc
+	return m
+}
+
+// matches reports whether e satisfies a query.
+func matches(e *lx.Entry, level lx.LevelType, msg string, matchers []Matcher) bool {
+	if level != AnyLevel && e.Level != level {
+		return false
+	}
+	if !strings.Contains(e.Message, msg) {
+		return false
+	}
+	for _, m := range matchers {
+		if !m.match(e) {
+			return false
+		}
+	}
+	return true
+}
+
+// describe renders a query for failure messages.
+func describe(level lx.LevelType, msg string, matchers []Matcher) string {
+	var sb strings.Builder
+	if level == AnyLevel {
+		sb.WriteString("at any level")
+	} else {
+		sb.WriteString("at " + level.String())
+	}
+	if msg != "" {
+		fmt.Fprintf(&sb, " containing %q", msg)
+	}
+	for i, m := range matchers {
+		if i == 0 {
+			sb.WriteString(" with ")
+		} else {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(m.desc)
+	}
+	return sb.String()
+}
+
+// valuesEqual compares a field value with an expected value, comparing numbers by value.
+func valuesEqual(got, want any) bool {
+	if reflect.DeepEqual(got, want) {
+		return true
+	}
+	gv, wv := reflect.ValueOf(got), reflect.ValueOf(want)
+	if !gv.IsValid() || !wv.IsValid() {
+		return false
+	}
+	switch {
+	case isInt(gv) && isInt(wv):
+		return gv.Int() == wv.Int()
+	case isUint(gv) && isUint(wv):
+		return gv.Uint() == wv.Uint()
+	case isInt(gv) && isUint(wv):
+		return gv.Int() >= 0 && uint64(gv.Int()) == wv.Uint()
+	case isUint(gv) && isInt(wv):
+		return wv.Int() >= 0 && gv.Uint() == uint64(wv.Int())
+	case isNumber(gv) && isNumber(wv):
+		return toFloat(gv) == toFloat(wv)
+	}
+	return false
+}
+
+// isInt reports whether v holds a signed integer.
+func isInt(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return true
+	}
+	return false
+}
+
+// isUint reports whether v holds an unsigned integer.
+func isUint(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	}
+	return false
+}
+
+// isNumber reports whether v holds an integer or a float.
+func isNumber(v reflect.Value) bool {
+	return isInt(v) || isUint(v) || v.Kind() == reflect.Float32 || v.Kind() == reflect.Float64
+}
+
+// toFloat converts a number to float64.
+func toFloat(v reflect.Value) float64 {
+	switch {
+	case isInt(v):
+		return float64(v.Int())
+	case isUint(v):
+		return float64(v.Uint())
+	default:
+		return v.Float()
+	}
+}
@@ -0,0 +1,162 @@
+package lltest
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/olekukonko/ll"
+	"github.com/olekukonko/ll/lx"
+)
+
+// fakeTB records failures and logs instead of failing the real test.
+type fakeTB struct {
+	testing.TB
+	errors   []string
+	fatals   []string
+	logs     []string
+	cleanups []func()
+	failed   bool
+}
+
+func (f *fakeTB) Helper() {}
+
+func (f *fakeTB) Errorf(format string, args ...any) {
+	f.failed = true
+	f.errors = append(f.errors, fmt.Sprintf(format, args...))
+}
+
+func (f *fakeTB) Fatalf(format string, args ...any) {
+	f.failed = true
+	f.fatals = append(f.fatals, fmt.Sprintf(format, args...))
+}
+
+func (f *fakeTB) Log(args ...any) { f.logs = append(f.logs, fmt.Sprint(args...)) }
+
+func (f *fakeTB) Cleanup(fn func()) { f.cleanups = append(f.cleanups, fn) }
+
+func (f *fakeTB) Failed() bool { return f.failed }
+
+// finish runs the registered cleanups, as the testing package does after a test.
+func (f *fakeTB) finish() {
+	for i := len(f.cleanups) - 1; i >= 0; i-- {
+		f.cleanups[i]()
+	}
+}
+
+// TestRecorder verifies queries, matchers and assertions.
+func TestRecorder(t *testing.T) {
+	ft := &fakeTB{}
+	logger, rec := New(ft)
+	logger.Fields("port", 8080, "host", "localhost").Info("server listening")
+	logger.Fields("took", 2*time.Second).Warn("slow start")
+	logger.Fields().Err(errors.New("disk full")).Error("write failed")
+	logger.Debug("debug detail")
+
+	if !rec.AssertLogged(ft, lx.LevelInfo, "listening", Field("port", 8080), FieldContains("host", "local")) {
+		t.Errorf("expected Info entry to match: %v", ft.errors)
+	}
+	if !rec.AssertLogged(ft, AnyLevel, "slow", Field("took", 2*time.Second), NoField("port")) {
+		t.Errorf("expected Warn entry to match: %v", ft.errors)
+	}
+	if !rec.AssertLogged(ft, lx.LevelError, "", Err("disk")) {
+		t.Errorf("expected error entry to match: %v", ft.errors)
+	}
+	if !rec.AssertNotLogged(ft, lx.LevelError, "listening") {
+		t.Errorf("expected no Error entry about listening: %v", ft.errors)
+	}
+	rec.RequireCount(ft, 5, AnyLevel, "") // Err logs the error itself too
+	rec.RequireCount(ft, 1, lx.LevelDebug, "detail")
+	if ft.failed {
+		t.Fatalf("unexpected failures: %v %v", ft.errors, ft.fatals)
+	}
+
+	if rec.AssertLogged(ft, lx.LevelInfo, "listening", Field("port", 9090)) {
+		t.Error("expected mismatched port to fail")
+	}
+	if len(ft.errors) != 1 || !strings.Contains(ft.errors[0], "at INFO containing \"listening\" with port=9090") ||
+		!strings.Contains(ft.errors[0], "server listening") {
+		t.Errorf("unexpected failure message: %v", ft.errors)
+	}
+	rec.RequireCount(ft, 2, lx.LevelWarn, "")
+	if len(ft.fatals) != 1 {
+		t.Errorf("expected RequireCount to fail fatally, got %v", ft.fatals)
+	}
+}
+
+// TestRecorder_PooledEntries verifies that recorded entries survive entry reuse.
+func TestRecorder_PooledEntries(t *testing.T) {
+	rec := NewRecorder()
+	logger := ll.New("app").Enable().Handler(rec)
+	for i := 0; i < 10; i++ {
+		logger.Fields("i", i).Infof("entry %d", i)
+	}
+	for i, e := range rec.Entries() {
+		if e.Message != fmt.Sprintf("entry %d", i) {
+			t.Fatalf("entry %d overwritten: %q", i, e.Message)
+		}
+	}
+}
+
+// TestTBHandler verifies that output is shown only for failed tests.
+func TestTBHandler(t *testing.T) {
+	passing := &fakeTB{}
+	ll.New("app").Enable().Handler(NewTBHandler(passing)).Info("quiet")
+	passing.finish()
+	if len(passing.logs) != 0 {
+		t.Errorf("expected no output for a passing test, got %v", passing.logs)
+	}
+
+	failing := &fakeTB{}
+	logger := ll.New("app").Enable().Handler(NewTBHandler(failing))
+	logger.Info("shown")
+	failing.Errorf("boom")
+	failing.finish()
+	if len(failing.logs) != 1 || !strings.Contains(failing.logs[0], "[app] INFO: shown") {
+		t.Errorf("expected output for a failed test, got %v", failing.logs)
+	}
+	logger.Info("after the test") // Must not panic or log
+	if len(failing.logs) != 1 {
+		t.Errorf("expected entries after the test to be discarded, got %v", failing.logs)
+	}
+}
+
+// TestScrubbers verifies that the built-in scrubbers normalize volatile output.
+func TestScrubbers(t *testing.T) {
+	in := "2024-05-01T12:30:00.123456Z took=1.5ms wait=2m3.5s ptr=0xc000012345 at 2024-05-01 12:30:00 +0000 UTC\n" +
+		"Jan  2 15:04:05.000 goroutine 42 [running]:\n" +
+		"main.run(0xc000123456)\n\t/home/dev/app/main.go:42 +0x1d\n"
+	want := "<TIME> took=<DURATION> wait=<DURATION> ptr=<PTR> at <TIME>\n" +
+		"Jan  2 <TIME> goroutine <N> [running]:\n" +
+		"main.run(<PTR>)\n\tmain.go:<LINE>\n"
+	got := []byte(in)
+	for _, scrub := range DefaultScrubbers() {
+		got = scrub(got)
+	}
+	if string(got) != want {
+		t.Errorf("unexpected scrubbed output:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+// TestUpdating tests the ways of enabling golden file updates without a package flag.
+func TestUpdating(t *testing.T) {
+	if flag.Lookup("update") != nil {
+		t.Fatal("lltest must not register an -update flag")
+	}
+	if updating() {
+		t.Fatal("expected updates to be off by default")
+	}
+	t.Setenv(updateEnv, "1")
+	if !updating() {
+		t.Error("expected LLTEST_UPDATE=1 to enable updates")
+	}
+	t.Setenv(updateEnv, "")
+	Update = true
+	defer func() { Update = false }()
+	if !updating() {
+		t.Error("expected Update to enable updates")
+	}
+}
@@ -0,0 +1,56 @@
+package lltest
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+
+	"github.com/olekukonko/ll/lh"
+	"github.com/olekukonko/ll/lx"
+)
+
+// TBHandler routes log output to a test. Entries are formatted as text and held until
+// the test finishes; they are written with t.Log only if the test failed, so passing
+// tests stay quiet even under go test -v. Thread-safe.
+type TBHandler struct {
+	t    testing.TB
+	mu   sync.Mutex
+	buf  bytes.Buffer
+	text *lh.TextHandler
+	done bool
+}
+
+// NewTBHandler creates a handler logging to t if it fails.
+// Example:
+//
+//	logger := ll.New("app").Enable().Handler(lltest.NewTBHandler(t))
+//	logger.Info("connecting") // Shown only if t fails
+func NewTBHandler(t testing.TB) *TBHandler {
+	h := &TBHandler{t: t}
+	h.text = lh.NewTextHandler(&h.buf)
+	t.Cleanup(h.flush)
+	return h
+}
+
+// Handle formats the entry and holds it until the test finishes. Entries logged after
+// the test finished are discarded.
+func (h *TBHandler) Handle(e *lx.Entry) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.done {
+		return nil
+	}
+	return h.text.Handle(e)
+}
+
+// flush writes the held output to the test if it failed.
+func (h *TBHandler) flush() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.done = true
+	if h.t.Failed() && h.buf.Len() > 0 {
+		h.t.Helper()
+		h.t.Log("log output:\n" + h.buf.String())
+	}
+	h.buf.Reset()
+}
@@ -0,0 +1,217 @@
+package lm
+
+import (
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/olekukonko/ll/lx"
+)
+
+// SampleRateKey is the field AdaptiveSampler adds to kept entries while sampling,
+// holding the probability (0.0 to 1.0] with which the entry was kept. Downstream
+// counts can be re-weighted by 1/sample_rate.
+const SampleRateKey = "sample_rate"
+
+// adaptiveOverflow is the namespace shared by buckets created past the key limit.
+const adaptiveOverflow = "*"
+
+// AdaptiveOpt configures an AdaptiveSampler.
+type AdaptiveOpt func(*AdaptiveSampler)
+
+// WithAdaptiveLevelBudget overrides the budget, in entries per second, for one level.
+func WithAdaptiveLevelBudget(level lx.LevelType, perSecond float64) AdaptiveOpt {
+	return func(s *AdaptiveSampler) {
+		s.levelBudgets[level] = perSecond
+	}
+}
+
+// WithAdaptiveWindow sets how often observed throughput is measured and the keep
+// probability recomputed (default: 1 second).
+func WithAdaptiveWindow(window time.Duration) AdaptiveOpt {
+	return func(s *AdaptiveSampler) {
+		if window > 0 {
+			s.window = window
+		}
+	}
+}
+
+// WithAdaptiveSmoothing sets the weight (0.0 to 1.0] given to the latest window when
+// averaging throughput. Lower values react more slowly to bursts (default: 0.5).
+func WithAdaptiveSmoothing(alpha float64) AdaptiveOpt {
+	return func(s *AdaptiveSampler) {
+		if alpha > 0 && alpha <= 1 {
+			s.alpha = alpha
+		}
+	}
+}
+
+// WithAdaptiveMaxKeys bounds how many namespace and level pairs are tracked separately.
+// Pairs beyond the limit share one bucket per level (default: 1024).
+func WithAdaptiveMaxKeys(max int) AdaptiveOpt {
+	return func(s *AdaptiveSampler) {
+		if max > 0 {
+			s.maxKeys = max
+		}
+	}
+}
+
+// AdaptiveSampler is a middleware that keeps the throughput of each namespace and level
+// under a budget by continuously adjusting the probability of keeping an entry. The
+// probability is recomputed every window from a moving average of observed entries per
+// second, so the same configuration holds through both traffic peaks and troughs.
+// Error and Fatal entries are never sampled. Kept entries are annotated with
+// SampleRateKey while the probability is below 1. Thread-safe.
+type AdaptiveSampler struct {
+	budget       float64
+	levelBudgets map[lx.LevelType]float64
+	window       time.Duration
+	alpha        float64
+	maxKeys      int
+
+	mu      sync.RWMutex
+	buckets map[adaptiveKey]*adaptiveBucket
+
+	kept    atomic.Uint64
+	dropped atomic.Uint64
+}
+
+// adaptiveKey identifies a throughput bucket.
+type adaptiveKey struct {
+	namespace string
+	level     lx.LevelType
+}
+
+// adaptiveBucket tracks throughput and the current keep probability for one key.
+type adaptiveBucket struct {
+	mu          sync.Mutex
+	windowStart time.Time
+	seen        float64 // Entries seen in the current window
+	average     float64 // Smoothed entries per second; 0 until the first window closes
+	probability float64 // Current keep probability
+}
+
+// NewAdaptiveSampler creates an AdaptiveSampler holding each namespace and level to
+// budget entries per second.
+// Example:
+//
+//	sampler := lm.NewAdaptiveSampler(100, lm.WithAdaptiveLevelBudget(lx.LevelDebug, 10))
+//	logger := ll.New("app").Enable().Use(sampler)
+func NewAdaptiveSampler(budget float64, opts ...AdaptiveOpt) *AdaptiveSampler {
+	s := &AdaptiveSampler{
+		budget:       budget,
+		levelBudgets: make(map[lx.LevelType]float64),
+		window:       time.Second,
+		alpha:        0.5,
+		maxKeys:      1024,
+		buckets:      make(map[adaptiveKey]*adaptiveBucket),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Handle keeps or drops the entry with the current probability for its namespace and
+// level, annotating kept entries with their sample rate. Returns an error for dropped entries.
+func (s *AdaptiveSampler) Handle(e *lx.Entry) error {
+	if e.Level == lx.LevelError || e.Level == lx.LevelFatal {
+		return nil
+	}
+
+	b := s.bucket(adaptiveKey{namespace: e.Namespace, level: e.Level})
+	p := b.observe(time.Now(), s.window, s.alpha, s.budgetFor(e.Level))
+	if p < 1 && rand.Float64() >= p {
+		s.dropped.Add(1)
+		return errSampled
+	}
+	s.kept.Add(1)
+	if p < 1 {
+		// Copy before appending: the fields may be shared with the logger's context.
+		e.Fields = append(e.Fields[:len(e.Fields):len(e.Fields)], lx.Float64(SampleRateKey, p))
+	}
+	return nil
+}
+
+// Gate marks the sampler as a gate, so entries kept by a recorder do not count toward the observed rate.
+func (s *AdaptiveSampler) Gate() {}
+
+// Rate returns the current keep probability for a namespace and level.
+func (s *AdaptiveSampler) Rate(namespace string, level lx.LevelType) float64 {
+	s.mu.RLock()
+	b, ok := s.buckets[adaptiveKey{namespace: namespace, level: level}]
+	s.mu.RUnlock()
+	if !ok {
+		return 1
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.probability
+}
+
+// GetStats returns the number of entries kept and dropped so far.
+func (s *AdaptiveSampler) GetStats() SamplerStats {
+	return SamplerStats{Kept: s.kept.Load(), Dropped: s.dropped.Load()}
+}
+
+// budgetFor returns the budget in entries per second for a level.
+func (s *AdaptiveSampler) budgetFor(level lx.LevelType) float64 {
+	if b, ok := s.levelBudgets[level]; ok {
+		return b
+	}
+	return s.budget
+}
+
+// bucket returns the bucket for key, creating it if needed. Once maxKeys buckets
+// exist, new namespaces share an overflow bucket for their level.
+func (s *AdaptiveSampler) bucket(key adaptiveKey) *adaptiveBucket {
+	s.mu.RLock()
+	b, ok := s.buckets[key]
+	s.mu.RUnlock()
+	if ok {
+		return b
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if b, ok = s.buckets[key]; ok {
+		return b
+	}
+	if len(s.buckets) >= s.maxKeys {
+		key.namespace = adaptiveOverflow
+		if b, ok = s.buckets[key]; ok {
+			return b
+		}
+	}
+	b = &adaptiveBucket{probability: 1}
+	s.buckets[key] = b
+	return b
+}
+
+// observe counts an entry at time now and returns the keep probability to apply,
+// closing the current window and recomputing the probability when it has elapsed.
+func (b *adaptiveBucket) observe(now time.Time, window time.Duration, alpha, budget float64) float64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.windowStart.IsZero() {
+		b.windowStart = now
+	}
+	if elapsed := now.Sub(b.windowStart); elapsed >= window {
+		rate := b.seen / elapsed.Seconds()
+		if b.average == 0 {
+			b.average = rate
+		} else {
+			b.average = alpha*rate + (1-alpha)*b.average
+		}
+		b.probability = 1
+		if b.average > budget {
+			b.probability = budget / b.average
+		}
+		b.windowStart = now
+		b.seen = 0
+	}
+	b.seen++
+	return b.probability
+}
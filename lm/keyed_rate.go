@@ -0,0 +1,298 @@
+package lm
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cespare/xxhash/v2"
+	"github.com/olekukonko/ll/lx"
+)
+
+// Field keys carried by suppression summaries.
+const (
+	SuppressedKey = "suppressed"     // Number of entries suppressed for the key
+	RateKeyKey    = "rate_limit_key" // Key the entries were limited by
+)
+
+// rateOverflowKey is the key shared by entries whose own key could not be tracked.
+const rateOverflowKey = "*"
+
+// errRateLimited is returned by KeyedRateLimiter.Handle for suppressed entries.
+var errRateLimited = errors.New("rate limit exceeded")
+
+// RateKeyFunc derives the key an entry is rate limited by.
+type RateKeyFunc func(e *lx.Entry) string
+
+// RateByLevel keys entries by level.
+func RateByLevel(e *lx.Entry) string {
+	return e.Level.String()
+}
+
+// RateByNamespace keys entries by namespace.
+func RateByNamespace(e *lx.Entry) string {
+	return e.Namespace
+}
+
+// RateByMessage keys entries by level and message, so each distinct message template
+// logged with structured fields is limited on its own.
+func RateByMessage(e *lx.Entry) string {
+	return e.Level.String() + ":" + e.Message
+}
+
+// RateByField keys entries by the value of the named field. Entries without the field
+// share the empty key.
+func RateByField(key string) RateKeyFunc {
+	return func(e *lx.Entry) string {
+		for _, f := range e.Fields {
+			if f.Key == key {
+				return fieldString(f)
+			}
+		}
+		return ""
+	}
+}
+
+// RateByCallsite keys entries by the file and line of the logging call. The caller is
+// found by walking the stack, so the limiter must run on the logging goroutine: as
+// logger middleware or in a pipe in front of a synchronous handler.
+func RateByCallsite(e *lx.Entry) string {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		fr, more := frames.Next()
+		if fr.Function != "" && !isLoggerFrame(fr.Function) {
+			return fr.File + ":" + strconv.Itoa(fr.Line)
+		}
+		if !more {
+			return ""
+		}
+	}
+}
+
+// isLoggerFrame reports whether fn belongs to the logger or one of its packages.
+func isLoggerFrame(fn string) bool {
+	const root = "github.com/olekukonko/ll"
+	if !strings.HasPrefix(fn, root) {
+		return false
+	}
+	rest := fn[len(root):]
+	return strings.HasPrefix(rest, ".") ||
+		strings.HasPrefix(rest, "/lh.") ||
+		strings.HasPrefix(rest, "/lm.") ||
+		strings.HasPrefix(rest, "/lx.")
+}
+
+// KeyedRateOpt configures a KeyedRateLimiter.
+type KeyedRateOpt func(*KeyedRateLimiter)
+
+// WithRateBurst sets how many entries a key may log at once before being limited
+// (default: the count passed to NewKeyedRateLimiter).
+func WithRateBurst(burst int) KeyedRateOpt {
+	return func(rl *KeyedRateLimiter) {
+		if burst > 0 {
+			rl.burst = float64(burst)
+		}
+	}
+}
+
+// WithRateMaxKeys bounds how many keys are tracked (default: 4096). Idle keys are
+// evicted to make room; when none are idle, new keys share a single overflow bucket.
+func WithRateMaxKeys(max int) KeyedRateOpt {
+	return func(rl *KeyedRateLimiter) {
+		if max > 0 {
+			rl.maxKeys = max
+		}
+	}
+}
+
+// KeyedRateLimiter limits entries per key with token bucket semantics: each key may log
+// a burst of entries, then count entries per interval as tokens refill. Suppressed
+// entries are counted, and when a key is allowed to log again, the count is reported
+// as a summary carrying the key (RateKeyKey) and the count (SuppressedKey).
+//
+// Used as logger middleware, which cannot emit entries of its own, the summary fields
+// are attached to the entry that reopens the key. Wrapped around a handler with
+// lh.PipeKeyedRate, a separate "suppressed N similar entries" entry is emitted instead.
+// Thread-safe.
+type KeyedRateLimiter struct {
+	keyFn   RateKeyFunc
+	rate    float64 // Tokens per nanosecond
+	burst   float64
+	sweep   int64 // Minimum nanoseconds between evictions in a shard
+	maxKeys int
+	noLimit bool // Set for a non-positive interval: every entry is allowed
+	shards  [shardCount]keyedRateShard
+}
+
+// keyedRateShard holds the buckets for a subset of keys.
+type keyedRateShard struct {
+	mu        sync.Mutex
+	buckets   map[string]*tokenBucket
+	lastSweep int64 // When idle buckets were last evicted, in unix nanoseconds
+}
+
+// tokenBucket holds the state of one key.
+type tokenBucket struct {
+	tokens     float64
+	last       int64  // Last refill, in unix nanoseconds
+	suppressed uint64 // Entries suppressed since the key last logged
+}
+
+// NewKeyedRateLimiter creates a KeyedRateLimiter allowing count entries per interval
+// for each key returned by keyFn. An interval of zero or less means no limit.
+// Example:
+//
+//	limiter := lm.NewKeyedRateLimiter(lm.RateByMessage, 10, time.Minute)
+//	logger := ll.New("app").Enable().Use(limiter)
+func NewKeyedRateLimiter(keyFn RateKeyFunc, count int, interval time.Duration, opts ...KeyedRateOpt) *KeyedRateLimiter {
+	rl := &KeyedRateLimiter{
+		keyFn:   keyFn,
+		burst:   float64(count),
+		sweep:   interval.Nanoseconds(),
+		maxKeys: 4096,
+		noLimit: interval <= 0,
+	}
+	if !rl.noLimit {
+		rl.rate = float64(count) / float64(interval.Nanoseconds())
+	}
+	for _, opt := range opts {
+		opt(rl)
+	}
+	for i := range rl.shards {
+		rl.shards[i].buckets = make(map[string]*tokenBucket)
+	}
+	return rl
+}
+
+// Handle enforces the limit as logger middleware. Suppressed entries are rejected with
+// an error; an entry reopening a key after suppression carries the summary fields.
+func (rl *KeyedRateLimiter) Handle(e *lx.Entry) error {
+	key, allowed, suppressed := rl.take(e)
+	if !allowed {
+		return errRateLimited
+	}
+	if suppressed > 0 {
+		// Copy before appending: the fields may be shared with the logger's context.
+		e.Fields = append(e.Fields[:len(e.Fields):len(e.Fields)],
+			lx.String(RateKeyKey, key), lx.Uint64(SuppressedKey, suppressed))
+	}
+	return nil
+}
+
+// Gate marks the limiter as a gate, so entries kept by a recorder do not spend tokens.
+func (rl *KeyedRateLimiter) Gate() {}
+
+// Take reports whether the entry may be logged. When it reopens a key after entries
+// were suppressed, summary is a new entry reporting them, to be logged before e.
+func (rl *KeyedRateLimiter) Take(e *lx.Entry) (summary *lx.Entry, allowed bool) {
+	key, allowed, suppressed := rl.take(e)
+	if suppressed > 0 {
+		summary = newSuppressedSummary(e, key, suppressed)
+	}
+	return summary, allowed
+}
+
+// Drain returns summaries for every key with suppressed entries not yet reported and
+// resets their counts. Use it on shutdown so trailing suppressions are not lost.
+// Summaries carry the time of the drain, no namespace and LevelWarn.
+func (rl *KeyedRateLimiter) Drain() []*lx.Entry {
+	var out []*lx.Entry
+	for i := range rl.shards {
+		shard := &rl.shards[i]
+		shard.mu.Lock()
+		for key, b := range shard.buckets {
+			if b.suppressed > 0 {
+				out = append(out, newSuppressedSummary(&lx.Entry{Level: lx.LevelWarn}, key, b.suppressed))
+				b.suppressed = 0
+			}
+		}
+		shard.mu.Unlock()
+	}
+	return out
+}
+
+// take spends a token for e's key, returning the key, whether the entry is allowed and,
+// if it is, how many entries were suppressed since the key last logged.
+func (rl *KeyedRateLimiter) take(e *lx.Entry) (key string, allowed bool, suppressed uint64) {
+	if rl.noLimit {
+		return "", true, 0
+	}
+	key = rl.keyFn(e)
+	shard := &rl.shards[xxhash.Sum64String(key)&(shardCount-1)]
+	now := time.Now().UnixNano()
+
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+
+	b, ok := shard.buckets[key]
+	if !ok {
+		if !rl.makeRoom(shard, now) {
+			key = rateOverflowKey
+			b, ok = shard.buckets[key]
+		}
+		if !ok {
+			b = &tokenBucket{tokens: rl.burst, last: now}
+			shard.buckets[key] = b
+		}
+	}
+
+	b.tokens = rl.refill(b, now)
+	b.last = now
+	if b.tokens < 1 {
+		b.suppressed++
+		return key, false, 0
+	}
+	b.tokens--
+	suppressed, b.suppressed = b.suppressed, 0
+	return key, true, suppressed
+}
+
+// refill returns the tokens in b at time now.
+func (rl *KeyedRateLimiter) refill(b *tokenBucket, now int64) float64 {
+	tokens := b.tokens + float64(now-b.last)*rl.rate
+	if tokens > rl.burst {
+		tokens = rl.burst
+	}
+	return tokens
+}
+
+// makeRoom reports whether shard can track another key, evicting idle keys (full
+// buckets with nothing to report) when it is at capacity. Evictions run at most once
+// per interval so a flood of new keys does not rescan the shard on every entry.
+// Caller must hold the shard lock.
+func (rl *KeyedRateLimiter) makeRoom(shard *keyedRateShard, now int64) bool {
+	limit := (rl.maxKeys + shardCount - 1) / shardCount
+	if len(shard.buckets) < limit {
+		return true
+	}
+	if now-shard.lastSweep < rl.sweep {
+		return false
+	}
+	shard.lastSweep = now
+	for key, b := range shard.buckets {
+		if b.suppressed == 0 && rl.refill(b, now) >= rl.burst {
+			delete(shard.buckets, key)
+		}
+	}
+	return len(shard.buckets) < limit
+}
+
+// newSuppressedSummary builds the summary entry for suppressed entries of key, modelled
+// on the entry that reopened it.
+func newSuppressedSummary(e *lx.Entry, key string, suppressed uint64) *lx.Entry {
+	return &lx.Entry{
+		Timestamp: time.Now(),
+		Level:     e.Level,
+		Message:   fmt.Sprintf("suppressed %d similar entries", suppressed),
+		Namespace: e.Namespace,
+		Style:     e.Style,
+		Class:     lx.ClassText,
+		Fields:    lx.Fields{lx.String(RateKeyKey, key), lx.Uint64(SuppressedKey, suppressed)},
+	}
+}
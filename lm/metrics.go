@@ -0,0 +1,281 @@
+package lm
+
+import (
+	"bufio"
+	"expvar"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/olekukonko/ll/lx"
+)
+
+// Metrics counts logging activity: entries by level, namespace and class, entries
+// dropped by middleware and handlers, and errors returned by handlers. The counts are
+// served in the Prometheus text exposition format by ServeHTTP and published to expvar
+// by Publish. Thread-safe.
+//
+// As middleware, Metrics counts the entries that reach it, so register it before
+// limiters and samplers to count everything logged, or after them to count what is
+// written. Drops are recorded by wrapping a middleware with Track, by calling Drop
+// (e.g. from lh.WithOverflowHandler), or by polling a counter with Observe (e.g.
+// lh.Dedup.Dropped). Handler errors are recorded by wrapping a sink with Wrap.
+// Example:
+//
+//	metrics := lm.NewMetrics()
+//	logger.Use(metrics)
+//	logger.Use(metrics.Track("rate", lm.NewRateLimiter(lx.LevelInfo, 100, time.Second)))
+//	logger.Handler(lh.Pipe(fileHandler, metrics.Wrap("file")))
+//	http.Handle("/metrics", metrics)
+type Metrics struct {
+	mu        sync.RWMutex
+	entries   map[metricsKey]*atomic.Uint64
+	dropped   map[string]*atomic.Uint64
+	errors    map[string]*atomic.Uint64
+	observers map[string]func() uint64
+}
+
+// metricsKey identifies an entry counter.
+type metricsKey struct {
+	level     lx.LevelType
+	namespace string
+	class     lx.ClassType
+}
+
+// MetricsSnapshot is a point-in-time copy of the counts held by Metrics.
+type MetricsSnapshot struct {
+	Entries       []EntryCount      `json:"entries"`
+	Dropped       map[string]uint64 `json:"dropped"`        // By source
+	HandlerErrors map[string]uint64 `json:"handler_errors"` // By sink
+}
+
+// EntryCount is the number of entries logged with a level, namespace and class.
+type EntryCount struct {
+	Level     string `json:"level"`
+	Namespace string `json:"namespace"`
+	Class     string `json:"class"`
+	Count     uint64 `json:"count"`
+}
+
+// NewMetrics creates an empty Metrics.
+func NewMetrics() *Metrics {
+	return &Metrics{
+		entries:   make(map[metricsKey]*atomic.Uint64),
+		dropped:   make(map[string]*atomic.Uint64),
+		errors:    make(map[string]*atomic.Uint64),
+		observers: make(map[string]func() uint64),
+	}
+}
+
+// Handle counts the entry by level, namespace and class. It never rejects entries.
+func (m *Metrics) Handle(e *lx.Entry) error {
+	key := metricsKey{level: e.Level, namespace: e.Namespace, class: e.Class}
+	m.mu.RLock()
+	c, ok := m.entries[key]
+	m.mu.RUnlock()
+	if !ok {
+		m.mu.Lock()
+		if c, ok = m.entries[key]; !ok {
+			c = new(atomic.Uint64)
+			m.entries[key] = c
+		}
+		m.mu.Unlock()
+	}
+	c.Add(1)
+	return nil
+}
+
+// Gate marks Metrics as a gate: entries kept by a recorder were not logged and are not counted.
+func (m *Metrics) Gate() {}
+
+// Track wraps a middleware so that every entry it rejects is counted as dropped by source.
+// The result is a gate (see lx.Gate) if mw is one.
+func (m *Metrics) Track(source string, mw lx.Handler) lx.Handler {
+	t := &tracked{next: mw, dropped: m.counter(m.dropped, source)}
+	if _, ok := mw.(lx.Gate); ok {
+		return trackedGate{t}
+	}
+	return t
+}
+
+// Drop counts an entry as dropped by source.
+func (m *Metrics) Drop(source string) {
+	m.counter(m.dropped, source).Add(1)
+}
+
+// Observe reports the value of fn as the drops of source, read whenever metrics are
+// collected. Use it for components keeping their own counter, such as lh.Dedup.Dropped
+// or lh.Buffered.Dropped.
+func (m *Metrics) Observe(source string, fn func() uint64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.observers[source] = fn
+}
+
+// Wrap returns a handler wrapper that counts the errors returned by the wrapped handler
+// as handler errors of sink. Errors are still returned to the caller.
+func (m *Metrics) Wrap(sink string) lx.Wrap {
+	return func(next lx.Handler) lx.Handler {
+		return &metricsSink{next: next, errors: m.counter(m.errors, sink)}
+	}
+}
+
+// Snapshot returns the current counts, with entries sorted by level, namespace and class.
+func (m *Metrics) Snapshot() MetricsSnapshot {
+	m.mu.RLock()
+	snap := MetricsSnapshot{
+		Entries:       make([]EntryCount, 0, len(m.entries)),
+		Dropped:       make(map[string]uint64, len(m.dropped)+len(m.observers)),
+		HandlerErrors: make(map[string]uint64, len(m.errors)),
+	}
+	for k, c := range m.entries {
+		snap.Entries = append(snap.Entries, EntryCount{
+			Level:     k.level.String(),
+			Namespace: k.namespace,
+			Class:     k.class.String(),
+			Count:     c.Load(),
+		})
+	}
+	for source, c := range m.dropped {
+		snap.Dropped[source] = c.Load()
+	}
+	observers := make(map[string]func() uint64, len(m.observers))
+	for source, fn := range m.observers {
+		observers[source] = fn
+	}
+	for sink, c := range m.errors {
+		snap.HandlerErrors[sink] = c.Load()
+	}
+	m.mu.RUnlock()
+
+	// Observers run unlocked: they may log, and logging may reach Handle.
+	for source, fn := range observers {
+		snap.Dropped[source] += fn()
+	}
+	sort.Slice(snap.Entries, func(i, j int) bool {
+		a, b := snap.Entries[i], snap.Entries[j]
+		if a.Level != b.Level {
+			return a.Level < b.Level
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Class < b.Class
+	})
+	return snap
+}
+
+// ServeHTTP writes the counts in the Prometheus text exposition format.
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	snap := m.Snapshot()
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+
+	bw := bufio.NewWriter(w)
+	bw.WriteString("# HELP ll_entries_total Log entries by level, namespace and class.\n")
+	bw.WriteString("# TYPE ll_entries_total counter\n")
+	for _, c := range snap.Entries {
+		bw.WriteString(`ll_entries_total{level="`)
+		bw.WriteString(promEscape(c.Level))
+		bw.WriteString(`",namespace="`)
+		bw.WriteString(promEscape(c.Namespace))
+		bw.WriteString(`",class="`)
+		bw.WriteString(promEscape(c.Class))
+		bw.WriteString(`"} `)
+		bw.WriteString(strconv.FormatUint(c.Count, 10))
+		bw.WriteByte('\n')
+	}
+	writePromCounter(bw, "ll_dropped_total", "Log entries dropped, by source.", "source", snap.Dropped)
+	writePromCounter(bw, "ll_handler_errors_total", "Errors returned by handlers, by sink.", "sink", snap.HandlerErrors)
+	bw.Flush()
+}
+
+// Publish exposes the counts through expvar under name, as returned by Snapshot.
+// Like expvar.Publish, it panics if name is already in use.
+func (m *Metrics) Publish(name string) {
+	expvar.Publish(name, expvar.Func(func() any { return m.Snapshot() }))
+}
+
+// counter returns the counter for name in counters, creating it if needed.
+func (m *Metrics) counter(counters map[string]*atomic.Uint64, name string) *atomic.Uint64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	c, ok := counters[name]
+	if !ok {
+		c = new(atomic.Uint64)
+		counters[name] = c
+	}
+	return c
+}
+
+// tracked counts the entries rejected by the middleware it wraps.
+type tracked struct {
+	next    lx.Handler
+	dropped *atomic.Uint64
+}
+
+// Handle forwards the entry to the wrapped middleware, counting it if rejected.
+func (t *tracked) Handle(e *lx.Entry) error {
+	err := t.next.Handle(e)
+	if err != nil {
+		t.dropped.Add(1)
+	}
+	return err
+}
+
+// trackedGate is a tracked gate, so it is still skipped for recorded entries.
+type trackedGate struct {
+	*tracked
+}
+
+// Gate marks the tracked middleware as a gate.
+func (trackedGate) Gate() {}
+
+// metricsSink counts the errors returned by the handler it wraps.
+type metricsSink struct {
+	next   lx.Handler
+	errors *atomic.Uint64
+}
+
+// Handle forwards the entry, counting any error returned.
+func (s *metricsSink) Handle(e *lx.Entry) error {
+	err := s.next.Handle(e)
+	if err != nil {
+		s.errors.Add(1)
+	}
+	return err
+}
+
+// Close closes the next handler if it implements a Close() error method.
+func (s *metricsSink) Close() error {
+	if c, ok := s.next.(interface{ Close() error }); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+// writePromCounter writes a counter family with one label, sorted by label value.
+func writePromCounter(bw *bufio.Writer, name, help, label string, values map[string]uint64) {
+	bw.WriteString("# HELP " + name + " " + help + "\n")
+	bw.WriteString("# TYPE " + name + " counter\n")
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		bw.WriteString(name + "{" + label + `="` + promEscape(k) + `"} `)
+		bw.WriteString(strconv.FormatUint(values[k], 10))
+		bw.WriteByte('\n')
+	}
+}
+
+// promEscaper escapes label values for the Prometheus text format.
+var promEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// promEscape escapes a label value.
+func promEscape(s string) string {
+	return promEscaper.Replace(s)
+}
@@ -111,6 +111,9 @@ func (rl *RateLimiter) Handle(e *lx.Entry) error {
 	return nil
 }
 
+// Gate marks the limiter as a gate, so entries kept by a recorder do not use up its limit.
+func (rl *RateLimiter) Gate() {}
+
 // Delete removes a rate limit for a specific level.
 func (rl *RateLimiter) Delete(level lx.LevelType) {
 	shard := rl.getShard(level)
@@ -0,0 +1,577 @@
+package lm
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/olekukonko/ll/lx"
+)
+
+// RedactAction defines what happens to a value matched by a redaction rule.
+type RedactAction int
+
+const (
+	RedactMask    RedactAction = iota // Replace the value with the mask string (default "[REDACTED]")
+	RedactPartial                     // Keep the last few characters and mask the rest with '*'
+	RedactHash                        // Replace the value with a keyed HMAC-SHA256 digest
+	RedactDrop                        // Remove the field; matches inside text are masked instead
+)
+
+// DefaultRedactMask is the replacement used by RedactMask unless overridden with Redact.Mask.
+const DefaultRedactMask = "[REDACTED]"
+
+// defaultReveal is the number of trailing characters kept by RedactPartial.
+const defaultReveal = 4
+
+// Built-in value patterns.
+var (
+	creditCardPattern = regexp.MustCompile(`\b(?:\d[ -]?){12,18}\d\b`)
+	emailPattern      = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+	jwtPattern        = regexp.MustCompile(`\beyJ[A-Za-z0-9_\-]+\.[A-Za-z0-9_\-]+\.[A-Za-z0-9_\-]*`)
+	bearerPattern     = regexp.MustCompile(`(?i)\bbearer\s+([A-Za-z0-9\-._~+/]+=*)`)
+	awsAccessPattern  = regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`)
+	awsSecretPattern  = regexp.MustCompile(`(?i)aws_?secret_?access_?key["']?\s*[:=]\s*["']?([A-Za-z0-9/+=]{40})`)
+)
+
+// RedactRule describes what to redact and how. Rules match either by field key
+// (exact, glob or regular expression) or by value pattern; value patterns are
+// applied to messages, errors and string field values. Build rules with the
+// RedactKey*, RedactValue and built-in pattern constructors.
+type RedactRule struct {
+	Action RedactAction // What to do with matched values
+	Reveal int          // Trailing characters kept by RedactPartial (default 4)
+
+	keys     []string         // Exact keys, compared case-insensitively
+	globs    []string         // Glob key patterns (path.Match), compared case-insensitively
+	keyRe    *regexp.Regexp   // Regular expression over keys
+	values   []*regexp.Regexp // Value patterns; if a pattern has a capture group, only group 1 is redacted
+	validate func(string) bool
+}
+
+// RedactKey creates a rule matching fields whose key equals one of keys (case-insensitive).
+// Example:
+//
+//	lm.RedactKey(lm.RedactDrop, "password", "secret")
+func RedactKey(action RedactAction, keys ...string) RedactRule {
+	lower := make([]string, len(keys))
+	for i, k := range keys {
+		lower[i] = strings.ToLower(k)
+	}
+	return RedactRule{Action: action, keys: lower}
+}
+
+// RedactKeyGlob creates a rule matching fields whose key matches one of the glob
+// patterns (case-insensitive), e.g. "*token*" or "x-api-*".
+func RedactKeyGlob(action RedactAction, patterns ...string) RedactRule {
+	lower := make([]string, len(patterns))
+	for i, p := range patterns {
+		lower[i] = strings.ToLower(p)
+	}
+	return RedactRule{Action: action, globs: lower}
+}
+
+// RedactKeyRegexp creates a rule matching fields whose key matches re.
+func RedactKeyRegexp(action RedactAction, re *regexp.Regexp) RedactRule {
+	return RedactRule{Action: action, keyRe: re}
+}
+
+// RedactValue creates a rule redacting text that matches re in messages and string
+// values. If re has a capture group, only the first group is redacted
+// (e.g., the token in "Bearer <token>").
+func RedactValue(action RedactAction, re *regexp.Regexp) RedactRule {
+	return RedactRule{Action: action, values: []*regexp.Regexp{re}}
+}
+
+// RedactCreditCards creates a rule for payment card numbers (13-19 digits, optionally
+// separated by spaces or dashes) that pass the Luhn checksum.
+func RedactCreditCards(action RedactAction) RedactRule {
+	return RedactRule{Action: action, values: []*regexp.Regexp{creditCardPattern}, validate: luhnValid}
+}
+
+// RedactEmails creates a rule for email addresses.
+func RedactEmails(action RedactAction) RedactRule {
+	return RedactRule{Action: action, values: []*regexp.Regexp{emailPattern}}
+}
+
+// RedactJWTs creates a rule for JSON Web Tokens.
+func RedactJWTs(action RedactAction) RedactRule {
+	return RedactRule{Action: action, values: []*regexp.Regexp{jwtPattern}}
+}
+
+// RedactBearerTokens creates a rule for the token part of "Bearer <token>" credentials.
+func RedactBearerTokens(action RedactAction) RedactRule {
+	return RedactRule{Action: action, values: []*regexp.Regexp{bearerPattern}}
+}
+
+// RedactAWSKeys creates a rule for AWS access key IDs and secret access keys.
+func RedactAWSKeys(action RedactAction) RedactRule {
+	return RedactRule{Action: action, values: []*regexp.Regexp{awsAccessPattern, awsSecretPattern}}
+}
+
+// WithReveal returns a copy of the rule that keeps n trailing characters when using RedactPartial.
+// Example:
+//
+//	lm.RedactCreditCards(lm.RedactPartial).WithReveal(4) // "************1111"
+func (r RedactRule) WithReveal(n int) RedactRule {
+	r.Reveal = n
+	return r
+}
+
+// matchesKey reports whether the rule targets the given field key.
+func (r *RedactRule) matchesKey(key string) bool {
+	if len(r.keys) == 0 && len(r.globs) == 0 && r.keyRe == nil {
+		return false
+	}
+	norm := strings.ToLower(normalizeKey(key))
+	for _, k := range r.keys {
+		if norm == k {
+			return true
+		}
+	}
+	for _, g := range r.globs {
+		if ok, _ := path.Match(g, norm); ok {
+			return true
+		}
+	}
+	return r.keyRe != nil && r.keyRe.MatchString(normalizeKey(key))
+}
+
+// Redact is a middleware that removes secrets and personal data from log entries.
+// It rewrites the message, error and fields of each entry according to its rules,
+// descending into nested maps and slices, and into the JSON payloads produced by
+// Logger.Output and Logger.Inspect. The logger's shared context is never modified;
+// a new Fields slice is built whenever something has to change.
+// Thread-safe with a read-write mutex protecting the rules.
+type Redact struct {
+	mu    sync.RWMutex
+	rules []RedactRule
+	key   []byte // HMAC key used by RedactHash
+	mask  string
+}
+
+// NewRedact creates a new Redact middleware with the given rules.
+// A random HMAC key is generated so hashed values are consistent within the process;
+// call HashKey to make them stable across processes.
+// Example:
+//
+//	redact := lm.NewRedact(
+//	    lm.RedactKey(lm.RedactDrop, "password"),
+//	    lm.RedactKeyGlob(lm.RedactMask, "*token*", "*secret*"),
+//	    lm.RedactCreditCards(lm.RedactPartial),
+//	    lm.RedactEmails(lm.RedactHash),
+//	)
+//	logger := ll.New("app").Enable()
+//	logger.Use(redact)
+func NewRedact(rules ...RedactRule) *Redact {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		key = []byte("ll/lm: redact")
+	}
+	return &Redact{
+		rules: rules,
+		key:   key,
+		mask:  DefaultRedactMask,
+	}
+}
+
+// Add appends rules to the middleware. Returns the Redact instance for chaining.
+func (r *Redact) Add(rules ...RedactRule) *Redact {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rules = append(r.rules, rules...)
+	return r
+}
+
+// HashKey sets the HMAC key used by RedactHash. Returns the Redact instance for chaining.
+func (r *Redact) HashKey(key []byte) *Redact {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.key = append([]byte(nil), key...)
+	return r
+}
+
+// Mask sets the replacement string used by RedactMask. Returns the Redact instance for chaining.
+func (r *Redact) Mask(mask string) *Redact {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.mask = mask
+	return r
+}
+
+// Handle redacts the entry in place. It never rejects an entry and always returns nil.
+// Example (internal usage):
+//
+//	redact.Handle(&lx.Entry{Message: "token=eyJhbGciOi..."}) // Message is rewritten
+func (r *Redact) Handle(e *lx.Entry) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	switch e.Class {
+	case lx.ClassOutput, lx.ClassInspect:
+		e.Message = r.redactPayload(e.Message)
+	default:
+		e.Message = r.redactString(e.Message)
+	}
+
+	if e.Error != nil {
+		if msg := e.Error.Error(); r.redactString(msg) != msg {
+			e.Error = errors.New(r.redactString(msg))
+		}
+	}
+
+	if fields, changed := r.redactFields(e.Fields); changed {
+		e.Fields = fields
+	}
+	return nil
+}
+
+// redactFields returns a redacted copy of fields and whether anything changed.
+// The input slice is never modified.
+func (r *Redact) redactFields(fields lx.Fields) (lx.Fields, bool) {
+	var out lx.Fields
+	for i, f := range fields {
+		value, drop, changed := r.redactKeyValue(f.Key, f.Interface())
+		if !changed && out == nil {
+			continue
+		}
+		if out == nil {
+			out = make(lx.Fields, i, len(fields))
+			copy(out, fields[:i])
+		}
+		if drop {
+			continue
+		}
+		if !changed {
+			out = append(out, f) // Keeps the field's kind
+			continue
+		}
+		out = append(out, redactedField(f.Key, value))
+	}
+	if out == nil {
+		return fields, false
+	}
+	return out, true
+}
+
+// redactedField builds the field holding a redacted value with the matching typed
+// constructor, so strings stay KindString and groups stay groups.
+func redactedField(key string, value interface{}) lx.Field {
+	switch v := value.(type) {
+	case string:
+		return lx.String(key, v)
+	case lx.Fields:
+		return lx.Group(key, v...)
+	default:
+		return lx.Any(key, v)
+	}
+}
+
+// redactKeyValue applies key rules, then value rules, to a single key/value pair.
+func (r *Redact) redactKeyValue(key string, value interface{}) (result interface{}, drop, changed bool) {
+	for i := range r.rules {
+		rule := &r.rules[i]
+		if !rule.matchesKey(key) {
+			continue
+		}
+		if rule.Action == RedactDrop {
+			return nil, true, true
+		}
+		return r.apply(rule, stringify(lx.Resolve(value))), false, true
+	}
+	result, changed = r.redactValue(value)
+	return result, false, changed
+}
+
+// redactValue applies rules to a value, descending into nested containers.
+func (r *Redact) redactValue(value interface{}) (interface{}, bool) {
+	switch v := value.(type) {
+	case nil:
+		return nil, false
+	case lx.LogValuer:
+		// Report a change so the resolved value replaces the LogValuer and
+		// is not computed a second time by the logger.
+		out, _ := r.redactValue(lx.Resolve(v))
+		return out, true
+	case string:
+		out := r.redactString(v)
+		return out, out != v
+	case error:
+		msg := v.Error()
+		if out := r.redactString(msg); out != msg {
+			return out, true
+		}
+		return v, false
+	case lx.Fields:
+		return r.redactFields(v)
+	case map[string]interface{}:
+		return r.redactMap(v)
+	case []interface{}:
+		return r.redactSlice(v)
+	case bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return v, false
+	}
+
+	// Generic maps with string keys and slices/arrays of any element type.
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			return value, false
+		}
+		m := make(map[string]interface{}, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			m[iter.Key().String()] = iter.Value().Interface()
+		}
+		if out, changed := r.redactMap(m); changed {
+			return out, true
+		}
+	case reflect.Slice, reflect.Array:
+		if rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() == reflect.Uint8 {
+			return value, false // []byte is binary data
+		}
+		s := make([]interface{}, rv.Len())
+		for i := range s {
+			s[i] = rv.Index(i).Interface()
+		}
+		if out, changed := r.redactSlice(s); changed {
+			return out, true
+		}
+	}
+	return value, false
+}
+
+// redactMap returns a redacted copy of m if any entry changed.
+func (r *Redact) redactMap(m map[string]interface{}) (interface{}, bool) {
+	var out map[string]interface{}
+	for k, v := range m {
+		value, drop, changed := r.redactKeyValue(k, v)
+		if !changed {
+			continue
+		}
+		if out == nil {
+			out = make(map[string]interface{}, len(m))
+			for k2, v2 := range m {
+				out[k2] = v2
+			}
+		}
+		if drop {
+			delete(out, k)
+		} else {
+			out[k] = value
+		}
+	}
+	if out == nil {
+		return m, false
+	}
+	return out, true
+}
+
+// redactSlice returns a redacted copy of s if any element changed.
+func (r *Redact) redactSlice(s []interface{}) (interface{}, bool) {
+	var out []interface{}
+	for i, v := range s {
+		value, changed := r.redactValue(v)
+		if !changed {
+			continue
+		}
+		if out == nil {
+			out = make([]interface{}, len(s))
+			copy(out, s)
+		}
+		out[i] = value
+	}
+	if out == nil {
+		return s, false
+	}
+	return out, true
+}
+
+// redactString applies every value rule to s.
+func (r *Redact) redactString(s string) string {
+	if s == "" {
+		return s
+	}
+	for i := range r.rules {
+		rule := &r.rules[i]
+		for _, re := range rule.values {
+			s = r.replace(rule, re, s)
+		}
+	}
+	return s
+}
+
+// replace redacts every match of re in s, honoring the rule's validator and capture group.
+func (r *Redact) replace(rule *RedactRule, re *regexp.Regexp, s string) string {
+	matches := re.FindAllStringSubmatchIndex(s, -1)
+	if len(matches) == 0 {
+		return s
+	}
+	var b strings.Builder
+	last := 0
+	for _, m := range matches {
+		start, end := m[0], m[1]
+		if len(m) >= 4 && m[2] >= 0 {
+			start, end = m[2], m[3] // redact the first capture group only
+		}
+		match := s[start:end]
+		if rule.validate != nil && !rule.validate(match) {
+			continue
+		}
+		b.WriteString(s[last:start])
+		b.WriteString(r.apply(rule, match))
+		last = end
+	}
+	if last == 0 {
+		return s
+	}
+	b.WriteString(s[last:])
+	return b.String()
+}
+
+// apply performs the rule's action on a matched value.
+func (r *Redact) apply(rule *RedactRule, s string) string {
+	switch rule.Action {
+	case RedactPartial:
+		reveal := rule.Reveal
+		if reveal <= 0 {
+			reveal = defaultReveal
+		}
+		runes := []rune(s)
+		if len(runes) <= reveal {
+			return strings.Repeat("*", len(runes))
+		}
+		return strings.Repeat("*", len(runes)-reveal) + string(runes[len(runes)-reveal:])
+	case RedactHash:
+		mac := hmac.New(sha256.New, r.key)
+		mac.Write([]byte(s))
+		return "hmac:" + hex.EncodeToString(mac.Sum(nil))[:16]
+	default: // RedactMask, and RedactDrop for matches inside text
+		return r.mask
+	}
+}
+
+// redactPayload redacts the JSON document embedded in Output and Inspect messages,
+// applying key rules to object keys before applying value rules to the text.
+func (r *Redact) redactPayload(msg string) string {
+	if !r.hasKeyRules() {
+		return r.redactString(msg)
+	}
+
+	// The payload follows a "[file:line]" header, so try each opening bracket
+	// until one starts a valid JSON document.
+	var (
+		doc   interface{}
+		dec   *json.Decoder
+		start = -1
+	)
+	for offset := 0; offset < len(msg); {
+		i := strings.IndexAny(msg[offset:], "{[")
+		if i < 0 {
+			break
+		}
+		candidate := offset + i
+		d := json.NewDecoder(strings.NewReader(msg[candidate:]))
+		d.UseNumber()
+		if err := d.Decode(&doc); err == nil {
+			start, dec = candidate, d
+			break
+		}
+		offset = candidate + 1
+	}
+	if start < 0 {
+		return r.redactString(msg)
+	}
+	out, changed := r.redactValue(doc)
+	if !changed {
+		return r.redactString(msg)
+	}
+
+	// Recover the line prefix used by the original payload ("  " for Output, "" for
+	// Inspect) from the indentation of its closing bracket.
+	header := msg[:start]
+	end := start + int(dec.InputOffset())
+	prefix := ""
+	if nl := strings.LastIndexByte(msg[start:end], '\n'); nl >= 0 {
+		last := msg[start+nl+1 : end]
+		prefix = last[:len(last)-len(strings.TrimLeft(last, " \t"))]
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent(prefix, "  ")
+	if err := enc.Encode(out); err != nil {
+		return r.redactString(msg)
+	}
+	rest := msg[end:]
+	return r.redactString(header + strings.TrimSuffix(buf.String(), "\n") + rest)
+}
+
+// hasKeyRules reports whether any rule matches by key.
+func (r *Redact) hasKeyRules() bool {
+	for i := range r.rules {
+		if len(r.rules[i].keys) > 0 || len(r.rules[i].globs) > 0 || r.rules[i].keyRe != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeKey strips the decorations Inspect adds to keys ("*(name)" for private pointers).
+func normalizeKey(key string) string {
+	key = strings.TrimPrefix(key, "*")
+	if len(key) > 2 && key[0] == '(' && key[len(key)-1] == ')' {
+		key = key[1 : len(key)-1]
+	}
+	return key
+}
+
+// stringify converts a value to a string for masking or hashing.
+func stringify(v interface{}) string {
+	switch val := v.(type) {
+	case string:
+		return val
+	case error:
+		return val.Error()
+	default:
+		return fmt.Sprint(val)
+	}
+}
+
+// luhnValid reports whether s (digits with optional spaces or dashes) is a
+// 13-19 digit number passing the Luhn checksum.
+func luhnValid(s string) bool {
+	sum, n := 0, 0
+	double := false
+	for i := len(s) - 1; i >= 0; i-- {
+		c := s[i]
+		if c == ' ' || c == '-' {
+			continue
+		}
+		if c < '0' || c > '9' {
+			return false
+		}
+		d := int(c - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+		n++
+	}
+	return n >= 13 && n <= 19 && sum%10 == 0
+}
@@ -1,10 +1,16 @@
 package lm
 
 import (
+	"errors"
 	"fmt"
+	"math"
 	"math/rand"
+	"strconv"
 	"sync"
+	"sync/atomic"
+	"time"
 
+	"github.com/cespare/xxhash/v2"
 	"github.com/olekukonko/ll/lx"
 )
 
@@ -76,6 +82,9 @@ func (s *Sampling) Handle(e *lx.Entry) error {
 	return fmt.Errorf("sampling error") // Reject log
 }
 
+// Gate marks Sampling as a gate, so entries kept by a recorder are not sampled.
+func (s *Sampling) Gate() {}
+
 // GetStats returns a copy of the sampling statistics.
 // It provides the count of rejected logs per level, ensuring thread-safety with a read lock.
 // The returned map is safe for external use without affecting internal state.
@@ -92,3 +101,166 @@ func (s *Sampling) GetStats() map[lx.LevelType]int {
 	}
 	return result
 }
+
+// errSampled is returned by the samplers below for dropped entries.
+var errSampled = errors.New("sampling error")
+
+// SamplerStats reports how many entries a sampler kept and dropped.
+type SamplerStats struct {
+	Kept    uint64 // Entries allowed through
+	Dropped uint64 // Entries rejected
+}
+
+// KeyedSampling is a middleware that samples deterministically on the value of a field,
+// such as a trace or request ID. Every entry carrying the same value gets the same
+// decision, so a request is either logged completely or not at all, and the decision
+// is stable across processes. Entries carrying none of the key fields are kept.
+// Thread-safe and lock-free.
+type KeyedSampling struct {
+	keys      []string // Fields to sample on, in order of preference
+	threshold uint64   // Keep entries whose key hash is below this value
+	all       bool     // Rate >= 1: keep everything
+	kept      atomic.Uint64
+	dropped   atomic.Uint64
+}
+
+// NewKeyedSampling creates a KeyedSampling middleware keeping the given fraction
+// (0.0 to 1.0) of key values. The first key field present on an entry is used.
+// Example:
+//
+//	sampler := NewKeyedSampling(0.1, "trace_id", "request_id") // Keep 10% of requests
+//	logger := ll.New("app").Enable().Use(sampler)
+func NewKeyedSampling(rate float64, keys ...string) *KeyedSampling {
+	s := &KeyedSampling{keys: keys}
+	switch {
+	case rate >= 1:
+		s.all = true
+	case rate > 0:
+		s.threshold = uint64(rate * math.MaxUint64)
+	}
+	return s
+}
+
+// Handle keeps or drops the entry based on the hash of its key field.
+// Returns an error for dropped entries.
+func (s *KeyedSampling) Handle(e *lx.Entry) error {
+	for _, key := range s.keys {
+		for _, f := range e.Fields {
+			if f.Key != key {
+				continue
+			}
+			if s.all || xxhash.Sum64String(fieldString(f)) < s.threshold {
+				s.kept.Add(1)
+				return nil
+			}
+			s.dropped.Add(1)
+			return errSampled
+		}
+	}
+	s.kept.Add(1) // No key field: nothing to keep together
+	return nil
+}
+
+// Gate marks KeyedSampling as a gate, so entries kept by a recorder are not sampled.
+func (s *KeyedSampling) Gate() {}
+
+// GetStats returns the number of entries kept and dropped so far.
+func (s *KeyedSampling) GetStats() SamplerStats {
+	return SamplerStats{Kept: s.kept.Load(), Dropped: s.dropped.Load()}
+}
+
+// burstCounters is the number of counters BurstSampling spreads messages over.
+// Distinct messages sharing a counter are sampled together.
+const burstCounters = 4096
+
+// BurstSampling is a middleware that, for each distinct level and message, keeps the
+// first N entries in every interval and then every Mth entry after that, like zap's
+// sampler. Memory use is fixed: messages are hashed onto a bounded set of counters.
+// Thread-safe and lock-free.
+type BurstSampling struct {
+	first      uint64
+	thereafter uint64
+	interval   int64
+	counters   [burstCounters]burstCounter
+	kept       atomic.Uint64
+	dropped    atomic.Uint64
+}
+
+// burstCounter counts entries for one message within the current interval.
+type burstCounter struct {
+	resetAt atomic.Int64
+	count   atomic.Uint64
+}
+
+// NewBurstSampling creates a BurstSampling middleware keeping the first entries per
+// message in each interval, then every thereafter-th one. A thereafter of 0 drops
+// everything past the first entries until the interval ends.
+// Example:
+//
+//	sampler := NewBurstSampling(100, 10, time.Second) // 100/s per message, then 1 in 10
+//	logger := ll.New("app").Enable().Use(sampler)
+func NewBurstSampling(first, thereafter int, interval time.Duration) *BurstSampling {
+	if first < 0 {
+		first = 0
+	}
+	if thereafter < 0 {
+		thereafter = 0
+	}
+	return &BurstSampling{
+		first:      uint64(first),
+		thereafter: uint64(thereafter),
+		interval:   interval.Nanoseconds(),
+	}
+}
+
+// Handle keeps or drops the entry based on how often its message was seen this interval.
+// Returns an error for dropped entries.
+func (s *BurstSampling) Handle(e *lx.Entry) error {
+	key := xxhash.Sum64String(e.Message) ^ uint64(e.Level)*0x9e3779b97f4a7c15
+	n := s.counters[key%burstCounters].inc(time.Now().UnixNano(), s.interval)
+	if n <= s.first || (s.thereafter > 0 && (n-s.first)%s.thereafter == 0) {
+		s.kept.Add(1)
+		return nil
+	}
+	s.dropped.Add(1)
+	return errSampled
+}
+
+// Gate marks BurstSampling as a gate, so entries kept by a recorder do not advance its counters.
+func (s *BurstSampling) Gate() {}
+
+// GetStats returns the number of entries kept and dropped so far.
+func (s *BurstSampling) GetStats() SamplerStats {
+	return SamplerStats{Kept: s.kept.Load(), Dropped: s.dropped.Load()}
+}
+
+// inc counts an entry at time now and returns its position within the current interval,
+// starting a new interval once the previous one has elapsed.
+func (c *burstCounter) inc(now, interval int64) uint64 {
+	resetAt := c.resetAt.Load()
+	if now < resetAt {
+		return c.count.Add(1)
+	}
+	if !c.resetAt.CompareAndSwap(resetAt, now+interval) {
+		// Another goroutine started the new interval.
+		return c.count.Add(1)
+	}
+	c.count.Store(1)
+	return 1
+}
+
+// fieldString returns a field's value as a string for hashing.
+func fieldString(f lx.Field) string {
+	switch f.Kind {
+	case lx.KindString:
+		return f.StringValue()
+	case lx.KindInt64:
+		return strconv.FormatInt(f.Int64Value(), 10)
+	case lx.KindUint64:
+		return strconv.FormatUint(f.Uint64Value(), 10)
+	}
+	if s, ok := f.Value.(string); ok && f.Kind == lx.KindAny {
+		return s
+	}
+	return fmt.Sprint(f.Interface())
+}
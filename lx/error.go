@@ -0,0 +1,191 @@
+package lx
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// StackTracer is implemented by errors that carry the stack trace of where they were
+// created. The returned program counters are those reported by runtime.Callers.
+// ErrorStack also recognizes the other common shapes; see its documentation.
+type StackTracer interface {
+	StackTrace() []uintptr
+}
+
+// maxErrorDepth bounds how deep DescribeError follows wrapped errors.
+const maxErrorDepth = 32
+
+// ErrorInfo describes an error, its concrete type, the stack trace it carries and
+// the errors it wraps. Errors wrapping a single error (errors.Unwrap) have one cause;
+// errors wrapping several (errors.Join, fmt.Errorf with multiple %w) have one per error.
+type ErrorInfo struct {
+	Message string       `json:"msg"`              // Result of Error()
+	Type    string       `json:"type"`             // Concrete type, e.g. "*fs.PathError"
+	Stack   []Frame      `json:"stack,omitempty"`  // Stack trace carried by the error
+	Causes  []*ErrorInfo `json:"causes,omitempty"` // Wrapped errors
+}
+
+// Frame is a single resolved stack frame.
+type Frame struct {
+	Function string `json:"func"`
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+}
+
+// DescribeError walks err's cause chain and returns its description, or nil for a nil error.
+// Example:
+//
+//	err := fmt.Errorf("load config: %w", os.ErrNotExist)
+//	info := lx.DescribeError(err)
+//	// info.Type == "*fmt.wrapError", info.Causes[0].Message == "file does not exist"
+func DescribeError(err error) *ErrorInfo {
+	return describeError(err, 0)
+}
+
+// describeError builds the description of err at the given depth of the chain.
+func describeError(err error, depth int) *ErrorInfo {
+	if err == nil {
+		return nil
+	}
+	info := &ErrorInfo{
+		Message: err.Error(),
+		Type:    fmt.Sprintf("%T", err),
+		Stack:   ErrorStack(err),
+	}
+	if depth >= maxErrorDepth {
+		return info
+	}
+	switch u := err.(type) {
+	case interface{ Unwrap() error }:
+		if cause := describeError(u.Unwrap(), depth+1); cause != nil {
+			info.Causes = []*ErrorInfo{cause}
+		}
+	case interface{ Unwrap() []error }:
+		for _, e := range u.Unwrap() {
+			if cause := describeError(e, depth+1); cause != nil {
+				info.Causes = append(info.Causes, cause)
+			}
+		}
+	}
+	return info
+}
+
+// ErrorStack returns the resolved stack trace carried by err itself (not its causes),
+// or nil if it has none. Besides StackTracer, it recognizes:
+//   - StackTrace methods returning a slice of a named program counter type, such as
+//     github.com/pkg/errors' StackTrace() errors.StackTrace;
+//   - StackTrace() []runtime.Frame;
+//   - errors implementing fmt.Formatter whose %+v output lists frames as a function
+//     line followed by a tab-indented "file:line" line, the pkg/errors convention.
+//     Wrappers are skipped: their %+v output includes the stack of their cause,
+//     which is reported with the cause.
+func ErrorStack(err error) []Frame {
+	switch st := err.(type) {
+	case StackTracer:
+		return framesFromPCs(st.StackTrace())
+	case interface{ StackTrace() []runtime.Frame }:
+		var stack []Frame
+		for _, f := range st.StackTrace() {
+			stack = append(stack, Frame{Function: f.Function, File: f.File, Line: f.Line})
+		}
+		return stack
+	}
+	if pcs, ok := reflectStackTrace(err); ok {
+		return framesFromPCs(pcs)
+	}
+	if f, ok := err.(fmt.Formatter); ok && !wraps(err) {
+		return parseFormattedStack(fmt.Sprintf("%+v", f))
+	}
+	return nil
+}
+
+// wraps reports whether err has a cause, through Unwrap() error or Unwrap() []error.
+func wraps(err error) bool {
+	switch u := err.(type) {
+	case interface{ Unwrap() error }:
+		return u.Unwrap() != nil
+	case interface{ Unwrap() []error }:
+		return len(u.Unwrap()) > 0
+	}
+	return false
+}
+
+// framesFromPCs resolves program counters reported by runtime.Callers.
+func framesFromPCs(pcs []uintptr) []Frame {
+	if len(pcs) == 0 {
+		return nil
+	}
+	frames := runtime.CallersFrames(pcs)
+	stack := make([]Frame, 0, len(pcs))
+	for {
+		frame, more := frames.Next()
+		stack = append(stack, Frame{Function: frame.Function, File: frame.File, Line: frame.Line})
+		if !more {
+			break
+		}
+	}
+	return stack
+}
+
+// reflectStackTrace calls a StackTrace method returning a slice of uintptr-based
+// values, such as pkg/errors' []Frame, and returns them as program counters.
+func reflectStackTrace(err error) ([]uintptr, bool) {
+	m := reflect.ValueOf(err).MethodByName("StackTrace")
+	if !m.IsValid() || m.Type().NumIn() != 0 || m.Type().NumOut() != 1 {
+		return nil, false
+	}
+	out := m.Type().Out(0)
+	if out.Kind() != reflect.Slice || out.Elem().Kind() != reflect.Uintptr {
+		return nil, false
+	}
+	v := m.Call(nil)[0]
+	pcs := make([]uintptr, v.Len())
+	for i := range pcs {
+		pcs[i] = uintptr(v.Index(i).Uint())
+	}
+	return pcs, true
+}
+
+// parseFormattedStack extracts the first run of frames from %+v output in the
+// "function\n\tfile:line" form. Lines that are not frames end the run.
+func parseFormattedStack(s string) []Frame {
+	lines := strings.Split(s, "\n")
+	var stack []Frame
+	for i := 0; i+1 < len(lines); i++ {
+		fn, loc := lines[i], lines[i+1]
+		frame, ok := parseFrame(fn, loc)
+		if !ok {
+			if stack != nil {
+				break
+			}
+			continue
+		}
+		stack = append(stack, frame)
+		i++
+	}
+	return stack
+}
+
+// parseFrame parses a function line and a tab-indented "file:line" line, ignoring a
+// trailing " +0x..." offset.
+func parseFrame(fn, loc string) (Frame, bool) {
+	if fn == "" || strings.HasPrefix(fn, "\t") || strings.Contains(fn, " ") || !strings.HasPrefix(loc, "\t") {
+		return Frame{}, false
+	}
+	loc = strings.TrimPrefix(loc, "\t")
+	if sp := strings.IndexByte(loc, ' '); sp >= 0 {
+		loc = loc[:sp]
+	}
+	colon := strings.LastIndexByte(loc, ':')
+	if colon <= 0 {
+		return Frame{}, false
+	}
+	line, err := strconv.Atoi(loc[colon+1:])
+	if err != nil {
+		return Frame{}, false
+	}
+	return Frame{Function: fn, File: loc[:colon], Line: line}, true
+}
@@ -2,13 +2,261 @@ package lx
 
 import (
 	"fmt"
+	"math"
 	"strings"
+	"time"
+)
+
+// FieldKind identifies how a Field stores its value.
+// Fields built with a struct literal or Any use KindAny and keep the value in Value;
+// the typed constructors (String, Int64, Bool, ...) store it unboxed so that building
+// and encoding them does not allocate.
+type FieldKind uint8
+
+const (
+	KindAny      FieldKind = iota // Value holds the value as interface{}
+	KindString                    // String value
+	KindInt64                     // Signed integer value
+	KindUint64                    // Unsigned integer value
+	KindFloat64                   // Floating-point value
+	KindBool                      // Boolean value
+	KindDuration                  // time.Duration value
+	KindTime                      // time.Time value (Value holds the *time.Location)
+	KindError                     // error value (Value holds the error)
+)
+
+// Bounds of the times representable as int64 Unix nanoseconds.
+var (
+	minUnixNanoTime = time.Unix(0, math.MinInt64)
+	maxUnixNanoTime = time.Unix(0, math.MaxInt64)
 )
 
 // Field represents a key-value pair where the key is a string and the value is of any type.
+// Use Interface to read the value regardless of how the field was built.
+// Field has unexported storage for the typed constructors, so struct literals must name
+// their fields: lx.Field{Key: "user", Value: "alice"}, or use lx.Any("user", "alice").
+// Unkeyed literals such as lx.Fields{{"user", "alice"}} do not compile.
 type Field struct {
 	Key   string
-	Value interface{}
+	Value interface{} // Value for KindAny fields; location or error for KindTime and KindError
+	Kind  FieldKind   // Storage kind, KindAny for struct literals
+	num   uint64      // Integer, float bits, bool, duration or unix-nano payload
+	str   string      // String payload
+}
+
+// String creates a string field without boxing the value.
+func String(key, value string) Field {
+	return Field{Key: key, Kind: KindString, str: value}
+}
+
+// Int creates an integer field without boxing the value.
+func Int(key string, value int) Field {
+	return Field{Key: key, Kind: KindInt64, num: uint64(value)}
+}
+
+// Int64 creates a 64-bit integer field without boxing the value.
+func Int64(key string, value int64) Field {
+	return Field{Key: key, Kind: KindInt64, num: uint64(value)}
+}
+
+// Uint64 creates an unsigned 64-bit integer field without boxing the value.
+func Uint64(key string, value uint64) Field {
+	return Field{Key: key, Kind: KindUint64, num: value}
+}
+
+// Float64 creates a floating-point field without boxing the value.
+func Float64(key string, value float64) Field {
+	return Field{Key: key, Kind: KindFloat64, num: math.Float64bits(value)}
+}
+
+// Bool creates a boolean field without boxing the value.
+func Bool(key string, value bool) Field {
+	var n uint64
+	if value {
+		n = 1
+	}
+	return Field{Key: key, Kind: KindBool, num: n}
+}
+
+// Duration creates a time.Duration field without boxing the value.
+func Duration(key string, value time.Duration) Field {
+	return Field{Key: key, Kind: KindDuration, num: uint64(value)}
+}
+
+// Time creates a time.Time field without boxing the value.
+// Times outside the range representable in Unix nanoseconds (years 1678-2262)
+// are stored boxed as KindAny.
+func Time(key string, value time.Time) Field {
+	if value.Before(minUnixNanoTime) || value.After(maxUnixNanoTime) {
+		return Field{Key: key, Value: value}
+	}
+	return Field{Key: key, Kind: KindTime, num: uint64(value.UnixNano()), Value: value.Location()}
+}
+
+// Err creates an error field. A nil error is kept as a nil value.
+func Err(key string, err error) Field {
+	return Field{Key: key, Kind: KindError, Value: err}
+}
+
+// Any creates a field holding an arbitrary value.
+func Any(key string, value interface{}) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Group creates a field that nests related fields under key. Handlers render groups
+// as nested objects (JSON, slog) or with dotted keys (text), e.g. http.method=GET.
+// Example:
+//
+//	lx.Group("http", lx.String("method", "GET"), lx.Int("status", 200))
+func Group(key string, fields ...Field) Field {
+	return Field{Key: key, Value: Fields(fields)}
+}
+
+// GroupValue returns the nested fields of a group field and whether f is a group.
+func (f Field) GroupValue() (Fields, bool) {
+	if f.Kind != KindAny {
+		return nil, false
+	}
+	g, ok := f.Value.(Fields)
+	return g, ok
+}
+
+// LogValuer is implemented by types that provide their own log representation,
+// like slog.LogValuer. Handlers call LogValue when the entry is written and log
+// the returned value in place of the original.
+type LogValuer interface {
+	LogValue() any
+}
+
+// Lazy defers computing a field value until an entry is actually written, so
+// expensive values cost nothing when the entry is filtered by level, namespace
+// or middleware.
+// Example:
+//
+//	logger.Fields("payload", lx.Lazy(func() any { return dump(req) })).Debug("request")
+type Lazy func() any
+
+// LogValue implements LogValuer by calling the function.
+func (l Lazy) LogValue() any {
+	if l == nil {
+		return nil
+	}
+	return l()
+}
+
+// maxResolveDepth bounds how many LogValuers Resolve follows before giving up.
+const maxResolveDepth = 100
+
+// Resolve returns v with any LogValuer replaced by the value it reports, following
+// chains of LogValuers. A panic inside LogValue is recovered and returned as an error.
+// Example:
+//
+//	lx.Resolve(lx.Lazy(func() any { return 42 })) // Returns 42
+func Resolve(v any) (out any) {
+	lv, ok := v.(LogValuer)
+	if !ok {
+		return v
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			out = fmt.Errorf("LogValue panicked: %v", r)
+		}
+	}()
+	for i := 0; i < maxResolveDepth; i++ {
+		v = lv.LogValue()
+		if lv, ok = v.(LogValuer); !ok {
+			return v
+		}
+	}
+	return fmt.Errorf("LogValue called too many times on type %T", v)
+}
+
+// Resolve resolves every LogValuer held by the field, returning it unchanged
+// when there is nothing to resolve.
+func (f Field) Resolve() Field {
+	if !f.needsResolve() {
+		return f
+	}
+	if g, ok := f.GroupValue(); ok {
+		return Group(f.Key, g.Resolve()...)
+	}
+	return Field{Key: f.Key, Value: Resolve(f.Value)}
+}
+
+// needsResolve reports whether the field, or any field nested in it, holds a LogValuer.
+func (f Field) needsResolve() bool {
+	if f.Kind != KindAny {
+		return false
+	}
+	switch v := f.Value.(type) {
+	case LogValuer:
+		return true
+	case Fields:
+		for _, nested := range v {
+			if nested.needsResolve() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Interface returns the field's value as interface{}, boxing typed values.
+// Example:
+//
+//	lx.Int64("n", 42).Interface() // Returns int64(42)
+func (f Field) Interface() interface{} {
+	switch f.Kind {
+	case KindString:
+		return f.str
+	case KindInt64:
+		return int64(f.num)
+	case KindUint64:
+		return f.num
+	case KindFloat64:
+		return math.Float64frombits(f.num)
+	case KindBool:
+		return f.num == 1
+	case KindDuration:
+		return time.Duration(f.num)
+	case KindTime:
+		return f.TimeValue()
+	default: // KindAny, KindError
+		return f.Value
+	}
+}
+
+// StringValue returns the value of a KindString field.
+func (f Field) StringValue() string { return f.str }
+
+// Int64Value returns the value of a KindInt64 field.
+func (f Field) Int64Value() int64 { return int64(f.num) }
+
+// Uint64Value returns the value of a KindUint64 field.
+func (f Field) Uint64Value() uint64 { return f.num }
+
+// Float64Value returns the value of a KindFloat64 field.
+func (f Field) Float64Value() float64 { return math.Float64frombits(f.num) }
+
+// BoolValue returns the value of a KindBool field.
+func (f Field) BoolValue() bool { return f.num == 1 }
+
+// DurationValue returns the value of a KindDuration field.
+func (f Field) DurationValue() time.Duration { return time.Duration(f.num) }
+
+// TimeValue returns the value of a KindTime field in its original location.
+func (f Field) TimeValue() time.Time {
+	t := time.Unix(0, int64(f.num))
+	if loc, ok := f.Value.(*time.Location); ok && loc != nil {
+		return t.In(loc)
+	}
+	return t
+}
+
+// ErrorValue returns the value of a KindError field.
+func (f Field) ErrorValue() error {
+	err, _ := f.Value.(error)
+	return err
 }
 
 // Fields represents a slice of key-value pairs.
@@ -16,14 +264,19 @@ type Fields []Field
 
 // Map converts the Fields slice to a map[string]interface{}.
 // This is useful for backward compatibility or when map operations are needed.
+// Groups are converted to nested maps.
 // Example:
 //
-//	fields := lx.Fields{{"user", "alice"}, {"age", 30}}
+//	fields := lx.Fields{lx.String("user", "alice"), lx.Int("age", 30)}
 //	m := fields.Map() // Returns map[string]interface{}{"user": "alice", "age": 30}
 func (f Fields) Map() map[string]interface{} {
 	m := make(map[string]interface{}, len(f))
 	for _, pair := range f {
-		m[pair.Key] = pair.Value
+		if g, ok := pair.GroupValue(); ok {
+			m[pair.Key] = g.Map()
+			continue
+		}
+		m[pair.Key] = pair.Interface()
 	}
 	return m
 }
@@ -32,28 +285,49 @@ func (f Fields) Map() map[string]interface{} {
 // This provides O(n) lookup, which is fine for small numbers of fields.
 // Example:
 //
-//	fields := lx.Fields{{"user", "alice"}, {"age", 30}}
+//	fields := lx.Fields{lx.String("user", "alice"), lx.Int("age", 30)}
 //	value, found := fields.Get("user") // Returns "alice", true
 func (f Fields) Get(key string) (interface{}, bool) {
 	for _, pair := range f {
 		if pair.Key == key {
-			return pair.Value, true
+			return pair.Interface(), true
 		}
 	}
 	return nil, false
 }
 
+// Resolve returns the fields with every LogValuer resolved. The receiver is never
+// modified; if no field needs resolving it is returned as-is without allocating.
+// Example:
+//
+//	fields := lx.Fields{lx.Any("n", lx.Lazy(func() any { return 1 }))}
+//	resolved := fields.Resolve() // Returns: [n=1]
+func (f Fields) Resolve() Fields {
+	for i, pair := range f {
+		if !pair.needsResolve() {
+			continue
+		}
+		out := make(Fields, len(f))
+		copy(out, f[:i])
+		for j := i; j < len(f); j++ {
+			out[j] = f[j].Resolve()
+		}
+		return out
+	}
+	return f
+}
+
 // Filter returns a new Fields slice containing only pairs where the predicate returns true.
 // Example:
 //
-//	fields := lx.Fields{{"user", "alice"}, {"password", "secret"}, {"age", 30}}
+//	fields := lx.Fields{lx.String("user", "alice"), lx.String("password", "secret"), lx.Int("age", 30)}
 //	filtered := fields.Filter(func(key string, value interface{}) bool {
 //	    return key != "password" // Remove sensitive fields
 //	})
 func (f Fields) Filter(predicate func(key string, value interface{}) bool) Fields {
 	result := make(Fields, 0, len(f))
 	for _, pair := range f {
-		if predicate(pair.Key, pair.Value) {
+		if predicate(pair.Key, pair.Interface()) {
 			result = append(result, pair)
 		}
 	}
@@ -64,17 +338,18 @@ func (f Fields) Filter(predicate func(key string, value interface{}) bool) Field
 // Keys not in the mapping are passed through unchanged. This is useful for adapters like Victoria.
 // Example:
 //
-//	fields := lx.Fields{{"user", "alice"}, {"timestamp", time.Now()}}
+//	fields := lx.Fields{lx.String("user", "alice"), lx.Time("timestamp", time.Now())}
 //	translated := fields.Translate(map[string]string{
 //	    "user": "username",
 //	    "timestamp": "ts",
 //	})
-//	// Returns: {{"username", "alice"}, {"ts", time.Now()}}
+//	// Returns: lx.Fields{lx.String("username", "alice"), lx.Time("ts", ...)}
 func (f Fields) Translate(mapping map[string]string) Fields {
 	result := make(Fields, len(f))
 	for i, pair := range f {
 		if newKey, ok := mapping[pair.Key]; ok {
-			result[i] = Field{Key: newKey, Value: pair.Value}
+			pair.Key = newKey
+			result[i] = pair
 		} else {
 			result[i] = pair
 		}
@@ -86,10 +361,10 @@ func (f Fields) Translate(mapping map[string]string) Fields {
 // for duplicate keys (overwrites existing keys).
 // Example:
 //
-//	base := lx.Fields{{"user", "alice"}, {"age", 30}}
-//	additional := lx.Fields{{"age", 31}, {"city", "NYC"}}
+//	base := lx.Fields{lx.String("user", "alice"), lx.Int("age", 30)}
+//	additional := lx.Fields{lx.Int("age", 31), lx.String("city", "NYC")}
 //	merged := base.Merge(additional)
-//	// Returns: {{"user", "alice"}, {"age", 31}, {"city", "NYC"}}
+//	// Returns: [user=alice age=31 city=NYC]
 func (f Fields) Merge(other Fields) Fields {
 	result := make(Fields, 0, len(f)+len(other))
 
@@ -122,7 +397,7 @@ func (f Fields) Merge(other Fields) Fields {
 // String returns a human-readable string representation of the fields.
 // Example:
 //
-//	fields := lx.Fields{{"user", "alice"}, {"age", 30}}
+//	fields := lx.Fields{lx.String("user", "alice"), lx.Int("age", 30)}
 //	str := fields.String() // Returns: "[user=alice age=30]"
 func (f Fields) String() string {
 	var builder strings.Builder
@@ -133,7 +408,7 @@ func (f Fields) String() string {
 		}
 		builder.WriteString(pair.Key)
 		builder.WriteString("=")
-		builder.WriteString(fmt.Sprint(pair.Value))
+		builder.WriteString(fmt.Sprint(pair.Interface()))
 	}
 	builder.WriteString(RightBracket)
 	return builder.String()
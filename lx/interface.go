@@ -17,6 +17,21 @@ type Handler interface {
 	Handle(e *Entry) error // Processes a log entry, returning any error
 }
 
+// HandlerFunc adapts a plain function to the Handler interface, for handlers,
+// middleware and pipe stages written inline.
+// Example:
+//
+//	h := lx.HandlerFunc(func(e *lx.Entry) error {
+//	    fmt.Println(e.Message)
+//	    return nil
+//	})
+type HandlerFunc func(e *Entry) error
+
+// Handle calls f(e).
+func (f HandlerFunc) Handle(e *Entry) error {
+	return f(e)
+}
+
 // Outputter defines the interface for handlers that support dynamic output
 // destination changes. Implementations can switch their output writer at runtime.
 //
@@ -70,3 +85,13 @@ type Wrap func(next Handler) Handler
 type Deduper interface {
 	Calculate(*Entry) uint64
 }
+
+// Gate is implemented by middleware that decides whether entries are logged, keeping
+// state across them, rather than transforming them: rate limiters, samplers and
+// metrics. Entries a logger hands to its recorder (see Logger.Recorder) were not
+// logged, so they skip gates and do not spend their budgets, but still pass through
+// every other middleware, such as redaction.
+type Gate interface {
+	Handler
+	Gate() // Marks the handler as a gate
+}
@@ -42,6 +42,28 @@ func (l LevelType) Name(class ClassType) string {
 	return l.String()
 }
 
+// Severity ranks the level by seriousness, in the order Debug (0) < Info < Warn < Error
+// < Fatal (4). Levels without a severity, LevelNone and LevelUnknown, return -1.
+// Example:
+//
+//	lx.LevelWarn.Severity() < lx.LevelError.Severity() // true
+func (l LevelType) Severity() int {
+	switch l {
+	case LevelDebug:
+		return 0
+	case LevelInfo:
+		return 1
+	case LevelWarn:
+		return 2
+	case LevelError:
+		return 3
+	case LevelFatal:
+		return 4
+	default:
+		return -1
+	}
+}
+
 // LevelParse converts a string to its corresponding LevelType.
 // It parses a string (case-insensitive) and returns the corresponding LevelType, defaulting to
 // LevelUnknown for unrecognized strings. Supports "WARNING" as an alias for "WARN".
@@ -55,6 +77,8 @@ func LevelParse(s string) LevelType {
 		return LevelWarn
 	case ErrorString:
 		return LevelError
+	case FatalString:
+		return LevelFatal
 	case NoneString:
 		return LevelNone
 	default:
@@ -1,6 +1,9 @@
 package ll
 
 import (
+	"errors"
+	"sync"
+
 	"github.com/olekukonko/ll/lx"
 )
 
@@ -96,21 +99,8 @@ func (m *Middleware) Errorf(format string, args ...any) *Middleware {
 	return m
 }
 
-// middlewareFunc is a function adapter that implements the lx.Handler interface.
-// It allows plain functions with the signature `func(*lx.Entry) error` to be used as middleware.
-// The function should return nil to allow the log to proceed or a non-nil error to reject it,
-// stopping the log from being emitted by the logger.
-type middlewareFunc func(*lx.Entry) error
-
-// Handle implements the lx.Handler interface for middlewareFunc.
-// It calls the underlying function with the log entry and returns its result.
-// This enables seamless integration of function-based middleware into the logging pipeline.
-func (mf middlewareFunc) Handle(e *lx.Entry) error {
-	return mf(e)
-}
-
 // Middle creates a middleware handler from a function.
-// It wraps a function with the signature `func(*lx.Entry) error` into a middlewareFunc,
+// It wraps a function with the signature `func(*lx.Entry) error` into an lx.HandlerFunc,
 // allowing it to be used in the logger’s middleware pipeline. A non-nil error returned by
 // the function will stop the log from being emitted, ensuring precise control over logging.
 // Example:
@@ -122,5 +112,100 @@ func (mf middlewareFunc) Handle(e *lx.Entry) error {
 //	    return nil
 //	}))
 func Middle(fn func(*lx.Entry) error) lx.Handler {
-	return middlewareFunc(fn)
+	return lx.HandlerFunc(fn)
+}
+
+// errWrapDropped is returned by wrap middleware when the wrapped stage did not forward the entry.
+var errWrapDropped = errors.New("entry dropped by wrap")
+
+// errWrapRejected is returned to a wrap forwarding an entry that MiddleWrap cannot pass
+// on: a second entry in the same call, or one forwarded outside of a call.
+var errWrapRejected = errors.New("entry rejected by wrap middleware: only the entry of the current call is forwarded")
+
+// wrapMiddleware adapts an lx.Wrap into logger middleware.
+// The wrap is applied once to an internal sink, so stateful wraps (dedup windows,
+// rate limits) keep their state across calls. Calls are serialized, so the entry
+// reaching the sink, or the copy forwarded in its place, belongs to the current call.
+type wrapMiddleware struct {
+	handler lx.Handler // Wrap applied to the sink
+	mu      sync.Mutex // Serializes calls through the wrap
+
+	sinkMu  sync.Mutex // Guards the fields below; the wrap may call the sink from any goroutine
+	current *lx.Entry  // Entry of the call in progress, nil between calls
+	passed  *lx.Entry  // Entry that reached the sink during the current call
+}
+
+// MiddleWrap turns a handler wrapper (lx.Wrap, e.g. lh.PipeFilter or lh.PipeMap) into
+// logger middleware, so the same stage can be used with lh.Pipe for a single sink or
+// with Logger.Use for every sink. An entry continues down the middleware chain only if
+// the wrap forwards it; changes a wrap makes to the entry (or to a copy it forwards)
+// are applied to the logged entry. Middleware passes on exactly one entry per call, so
+// the wrap must forward at most one entry, synchronously: extra entries, such as the
+// summaries of lh.WithDedupSummary and lh.PipeKeyedRate, and entries forwarded from
+// other goroutines, such as by lh.PipeBuffer, are rejected. Use those wraps with
+// lh.Pipe instead.
+// Example:
+//
+//	logger.Use(ll.MiddleWrap(lh.PipeFilter(func(e *lx.Entry) bool {
+//	    return e.Namespace != "health"
+//	})))
+//	logger.Use(ll.MiddleWrap(lh.PipeMap(func(e *lx.Entry) {
+//	    e.Message = strings.TrimSpace(e.Message)
+//	})))
+func MiddleWrap(w lx.Wrap) lx.Handler {
+	m := &wrapMiddleware{}
+	m.handler = w(lx.HandlerFunc(m.sink))
+	return m
+}
+
+// sink records the entry forwarded by the wrap for the current call. The entry of the
+// call itself is preferred over anything the wrap forwarded before it.
+func (m *wrapMiddleware) sink(e *lx.Entry) error {
+	m.sinkMu.Lock()
+	defer m.sinkMu.Unlock()
+	switch {
+	case m.current == nil:
+		return errWrapRejected
+	case m.passed == nil || e == m.current:
+		m.passed = e
+		return nil
+	default:
+		return errWrapRejected
+	}
+}
+
+// Handle runs the entry through the wrap, returning an error if the wrap dropped it.
+func (m *wrapMiddleware) Handle(e *lx.Entry) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sinkMu.Lock()
+	m.current, m.passed = e, nil
+	m.sinkMu.Unlock()
+
+	err := m.handler.Handle(e)
+
+	m.sinkMu.Lock()
+	out := m.passed
+	m.current, m.passed = nil, nil
+	m.sinkMu.Unlock()
+
+	if out == nil {
+		if err != nil {
+			return err
+		}
+		return errWrapDropped
+	}
+	if out != e {
+		*e = *out
+	}
+	return nil
+}
+
+// Close closes the wrapped handler if it implements a Close() error method,
+// releasing resources such as dedup cleanup goroutines.
+func (m *wrapMiddleware) Close() error {
+	if c, ok := m.handler.(interface{ Close() error }); ok {
+		return c.Close()
+	}
+	return nil
 }
@@ -67,3 +67,25 @@ func WithFatalStack(enabled bool) Option {
 		l.fatalStack = enabled
 	}
 }
+
+// WithSpanStarts makes spans log an entry when they start as well as when they end.
+// By default only the end entry is logged; it carries the start time and duration.
+// Example:
+//
+//	logger := New("app", WithSpanStarts(true))
+func WithSpanStarts(enabled bool) Option {
+	return func(l *Logger) {
+		l.spanStarts = enabled
+	}
+}
+
+// WithRecorder attaches a handler receiving the entries the level filter drops, such as
+// an lh.FlightRecorder. See Logger.Recorder.
+// Example:
+//
+//	logger := New("app", WithLevel(lx.LevelInfo), WithRecorder(flight))
+func WithRecorder(recorder lx.Handler) Option {
+	return func(l *Logger) {
+		l.Recorder(recorder)
+	}
+}
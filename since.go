@@ -63,15 +63,11 @@ func (l *Logger) Measure(fns ...func()) time.Duration {
 		}
 		// Use SinceBuilder instead of manual timing
 		sb := l.Since() // starts timer internally
+		sb.label = measureLabel(lbls, i)
 		fn()
 		duration := sb.Fields(
 			"index", i,
-		).Info(fmt.Sprintf("[%s] completed", func() string {
-			if i < len(lbls) && lbls[i] != "" {
-				return lbls[i]
-			}
-			return fmt.Sprintf("fn_%d", i)
-		}()))
+		).Info(fmt.Sprintf("[%s] completed", sb.label))
 
 		total += duration
 	}
@@ -79,6 +75,14 @@ func (l *Logger) Measure(fns ...func()) time.Duration {
 	return total
 }
 
+// measureLabel returns the label of the i-th function passed to Measure.
+func measureLabel(lbls []string, i int) string {
+	if i < len(lbls) && lbls[i] != "" {
+		return lbls[i]
+	}
+	return fmt.Sprintf("fn_%d", i)
+}
+
 // Since creates a timer that will log the duration when completed
 // If startTime is provided, uses that as the start time; otherwise uses time.Now()
 //
@@ -106,6 +110,7 @@ type SinceBuilder struct {
 	start     time.Time
 	condition bool
 	fields    lx.Fields
+	label     string // Timing aggregation label; the message when empty
 }
 
 // ---------------------------------------------------------------------
@@ -321,12 +326,34 @@ func (sb *SinceBuilder) Log(msg string) time.Duration {
 // logAtLevel internal method that handles the actual logging
 func (sb *SinceBuilder) logAtLevel(level lx.LevelType, msg string) time.Duration {
 	// Fast path - don't even compute duration if we're not logging
-	if !sb.condition || sb.logger.suspend.Load() || !sb.logger.shouldLog(level) {
+	if !sb.condition || sb.logger.suspend.Load() {
 		return time.Since(sb.start)
 	}
+	// Entries below the level may still go to a recorder, but are not timings
+	recorded := false
+	if !sb.logger.shouldLog(level) {
+		if !sb.logger.shouldRecord(level) {
+			return time.Since(sb.start)
+		}
+		recorded = true
+	}
 
 	duration := time.Since(sb.start)
 
+	sb.logger.mu.RLock()
+	timings := sb.logger.timings
+	sb.logger.mu.RUnlock()
+	if timings != nil && !recorded {
+		label := sb.label
+		if label == "" {
+			label = msg
+		}
+		timings.Record(sb.logger.currentPath, label, duration)
+		if !timings.entries {
+			return duration
+		}
+	}
+
 	// Build final fields in this order:
 	// 1. Logger context fields (from logger.context)
 	// 2. Builder fields (from sb.fields)
@@ -368,6 +395,30 @@ func (sb *SinceBuilder) logAtLevel(level lx.LevelType, msg string) time.Duration
 // Utility Methods
 // ---------------------------------------------------------------------
 
+// Record adds the elapsed duration to the timing aggregator attached to the logger
+// under label, without logging an entry. Conditions are respected. Returns the duration.
+// Example:
+//
+//	for _, row := range rows {
+//	    sb := logger.Since()
+//	    insert(row)
+//	    sb.Record("insert")
+//	}
+func (sb *SinceBuilder) Record(label string) time.Duration {
+	duration := time.Since(sb.start)
+	if !sb.condition || sb.logger.suspend.Load() {
+		return duration
+	}
+	sb.logger.mu.RLock()
+	timings := sb.logger.timings
+	path := sb.logger.currentPath
+	sb.logger.mu.RUnlock()
+	if timings != nil {
+		timings.Record(path, label, duration)
+	}
+	return duration
+}
+
 // Reset allows reusing the builder with a new start time
 // Zero-allocation - keeps fields slice capacity
 func (sb *SinceBuilder) Reset(startTime ...time.Time) *SinceBuilder {
@@ -0,0 +1,210 @@
+package ll
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"sync/atomic"
+	"time"
+
+	"github.com/olekukonko/ll/lx"
+)
+
+// Field keys used by spans.
+const (
+	TraceIDKey  = "trace_id"  // Trace the span belongs to
+	SpanIDKey   = "span_id"   // The span itself
+	ParentIDKey = "parent_id" // The span's parent, absent for root spans
+)
+
+// SpanContext identifies a span within a trace. IDs use the W3C Trace Context format:
+// 32 lowercase hex digits for the trace and 16 for the span.
+type SpanContext struct {
+	TraceID    string
+	SpanID     string
+	Flags      byte   // W3C trace flags; bit 0 is "sampled"
+	TraceState string // W3C tracestate header, propagated as-is
+}
+
+// IsValid reports whether both IDs are set.
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID != "" && sc.SpanID != ""
+}
+
+// spanContextKey is the context key under which the current SpanContext is stored.
+type spanContextKey struct{}
+
+// ContextWithSpanContext returns a copy of ctx carrying sc as the current span, so spans
+// started from it become its children and Logger.Ctx attaches its IDs.
+func ContextWithSpanContext(ctx context.Context, sc SpanContext) context.Context {
+	return context.WithValue(ctx, spanContextKey{}, sc)
+}
+
+// SpanContextFrom returns the current span carried by ctx, if any.
+func SpanContextFrom(ctx context.Context) (SpanContext, bool) {
+	if ctx == nil {
+		return SpanContext{}, false
+	}
+	sc, ok := ctx.Value(spanContextKey{}).(SpanContext)
+	return sc, ok && sc.IsValid()
+}
+
+// Span is a timed operation within a trace. It is built on SinceBuilder: attributes
+// added with Fields are logged with the span's end entry, together with its duration,
+// status and IDs. Entries logged through Span.Logger, or through Logger.Ctx with the
+// span's context, carry trace_id and span_id, so a trace's entries can be rebuilt into
+// a waterfall from the span_id/parent_id links and the start and duration fields.
+type Span struct {
+	since    *SinceBuilder // Start time, attributes and the logger carrying the IDs
+	logger   *Logger       // Logger the span was started from, whose Suspend applies
+	name     string
+	sc       SpanContext
+	parentID string
+	err      error
+	ended    atomic.Bool
+}
+
+// Span starts a span named name as a child of the span carried by ctx, or as the root of
+// a new trace. It returns a context carrying the new span, for nesting and for Ctx.
+// Unless the logger was created with WithSpanStarts, only the end entry is logged.
+// Example:
+//
+//	ctx, span := logger.Span(ctx, "checkout")
+//	defer span.End()
+//	span.Fields("order_id", id)
+//	logger.Ctx(ctx).Info("charging card") // Carries trace_id and span_id
+func (l *Logger) Span(ctx context.Context, name string) (context.Context, *Span) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	s := &Span{name: name, logger: l}
+	if parent, ok := SpanContextFrom(ctx); ok {
+		s.sc = parent
+		s.parentID = parent.SpanID
+	} else {
+		s.sc.TraceID = newTraceID()
+		s.sc.Flags = traceFlagSampled
+	}
+	s.sc.SpanID = newSpanID()
+
+	s.since = l.withContext(lx.String(TraceIDKey, s.sc.TraceID), lx.String(SpanIDKey, s.sc.SpanID)).Since()
+
+	l.mu.RLock()
+	starts := l.spanStarts
+	l.mu.RUnlock()
+	if starts && !l.suspend.Load() {
+		s.since.logger.log(lx.LevelInfo, lx.ClassTimed, name, s.linkFields(lx.String("span", "start")), false)
+	}
+	return ContextWithSpanContext(ctx, s.sc), s
+}
+
+// Ctx returns a logger whose entries carry the trace_id and span_id of the span in ctx.
+// Without a span, the logger itself is returned.
+// Example:
+//
+//	func handle(ctx context.Context) {
+//	    logger.Ctx(ctx).Info("loading profile")
+//	}
+func (l *Logger) Ctx(ctx context.Context) *Logger {
+	sc, ok := SpanContextFrom(ctx)
+	if !ok {
+		return l
+	}
+	return l.withContext(lx.String(TraceIDKey, sc.TraceID), lx.String(SpanIDKey, sc.SpanID))
+}
+
+// withContext returns a child logger with fields appended to its context.
+func (l *Logger) withContext(fields ...lx.Field) *Logger {
+	child := l.Context(nil)
+	child.context = append(child.context, fields...)
+	return child
+}
+
+// Context returns the span's IDs.
+func (s *Span) Context() SpanContext {
+	return s.sc
+}
+
+// ParentID returns the ID of the parent span, or "" for a root span.
+func (s *Span) ParentID() string {
+	return s.parentID
+}
+
+// Logger returns a logger whose entries carry the span's trace_id and span_id.
+func (s *Span) Logger() *Logger {
+	return s.since.logger
+}
+
+// Elapsed returns the time since the span started.
+func (s *Span) Elapsed() time.Duration {
+	return s.since.Elapsed()
+}
+
+// Fields adds attributes to the span's end entry.
+func (s *Span) Fields(pairs ...any) *Span {
+	s.since.Merge(pairs...)
+	return s
+}
+
+// Fail marks the span as failed: its end entry is logged as an Error text entry, not
+// a timing, with status "error" and err attached. A nil err leaves the span unchanged.
+func (s *Span) Fail(err error) *Span {
+	if err != nil {
+		s.err = err
+	}
+	return s
+}
+
+// End logs the span's end entry with its start time, duration, status and attributes,
+// and returns the duration. Only the first call logs.
+func (s *Span) End() time.Duration {
+	duration := s.Elapsed()
+	if !s.ended.CompareAndSwap(false, true) || s.logger.suspend.Load() {
+		return duration
+	}
+
+	// A failed span is an error, not a timing: it is rendered as ERROR, not TIMED
+	level, class, status := lx.LevelInfo, lx.ClassTimed, "ok"
+	if s.err != nil {
+		level, class, status = lx.LevelError, lx.ClassText, "error"
+	}
+	fields := s.linkFields(
+		lx.String("span", "end"),
+		lx.Time("start", s.since.start),
+	)
+	fields = append(fields, s.since.fields...)
+	fields = append(fields,
+		lx.String("status", status),
+		lx.Int64("duration_ms", duration.Milliseconds()),
+		lx.Duration("duration", duration),
+	)
+	s.since.logger.logErr(level, class, s.name, fields, s.err, false)
+	return duration
+}
+
+// linkFields returns the parent link, if any, followed by extra.
+func (s *Span) linkFields(extra ...lx.Field) lx.Fields {
+	fields := make(lx.Fields, 0, len(extra)+len(s.since.fields)+4)
+	if s.parentID != "" {
+		fields = append(fields, lx.String(ParentIDKey, s.parentID))
+	}
+	return append(fields, extra...)
+}
+
+// newTraceID returns a random, non-zero 16-byte trace ID in hex.
+func newTraceID() string {
+	var b [16]byte
+	for b == [16]byte{} {
+		rand.Read(b[:])
+	}
+	return hex.EncodeToString(b[:])
+}
+
+// newSpanID returns a random, non-zero 8-byte span ID in hex.
+func newSpanID() string {
+	var b [8]byte
+	for b == [8]byte{} {
+		rand.Read(b[:])
+	}
+	return hex.EncodeToString(b[:])
+}
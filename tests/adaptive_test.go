@@ -0,0 +1,82 @@
+package tests
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/olekukonko/ll"
+	"github.com/olekukonko/ll/lh"
+	"github.com/olekukonko/ll/lm"
+	"github.com/olekukonko/ll/lx"
+)
+
+// TestAdaptiveSampler_HoldsBudget verifies that the keep probability adapts to throughput.
+func TestAdaptiveSampler_HoldsBudget(t *testing.T) {
+	window := 20 * time.Millisecond
+	sampler := lm.NewAdaptiveSampler(1000, lm.WithAdaptiveWindow(window), lm.WithAdaptiveSmoothing(1))
+
+	// ~200 entries per 20ms window is ~10,000/s, ten times the budget.
+	flood := func(ns string, level lx.LevelType) {
+		deadline := time.Now().Add(3 * window)
+		for time.Now().Before(deadline) {
+			for i := 0; i < 200; i++ {
+				sampler.Handle(&lx.Entry{Namespace: ns, Level: level})
+			}
+			time.Sleep(window)
+		}
+	}
+	flood("app/api", lx.LevelInfo)
+
+	rate := sampler.Rate("app/api", lx.LevelInfo)
+	if rate >= 0.5 || rate <= 0 {
+		t.Fatalf("expected keep probability well below 1 under load, got %v", rate)
+	}
+	if other := sampler.Rate("app/db", lx.LevelInfo); other != 1 {
+		t.Errorf("expected untouched namespace to keep everything, got %v", other)
+	}
+
+	// Kept entries carry their sample rate.
+	var annotated *lx.Entry
+	for i := 0; i < 1000 && annotated == nil; i++ {
+		e := &lx.Entry{Namespace: "app/api", Level: lx.LevelInfo}
+		if sampler.Handle(e) == nil {
+			annotated = e
+		}
+	}
+	if annotated == nil {
+		t.Fatal("expected at least one kept entry")
+	}
+	if v, ok := annotated.Fields.Get(lm.SampleRateKey); !ok || v.(float64) >= 1 {
+		t.Errorf("expected %s annotation below 1, got %v", lm.SampleRateKey, annotated.Fields)
+	}
+
+	// Traffic drops: the probability recovers.
+	time.Sleep(2 * window)
+	sampler.Handle(&lx.Entry{Namespace: "app/api", Level: lx.LevelInfo})
+	time.Sleep(window)
+	sampler.Handle(&lx.Entry{Namespace: "app/api", Level: lx.LevelInfo})
+	if rate := sampler.Rate("app/api", lx.LevelInfo); rate != 1 {
+		t.Errorf("expected probability to recover at low traffic, got %v", rate)
+	}
+}
+
+// TestAdaptiveSampler_NeverSamplesErrors verifies that Error and Fatal entries always pass.
+func TestAdaptiveSampler_NeverSamplesErrors(t *testing.T) {
+	sampler := lm.NewAdaptiveSampler(0.001, lm.WithAdaptiveWindow(time.Millisecond))
+
+	buf := &bytes.Buffer{}
+	logger := ll.New("app").Enable().Handler(lh.NewTextHandler(buf))
+	logger.Use(sampler)
+	for i := 0; i < 50; i++ {
+		logger.Error("boom")
+		time.Sleep(100 * time.Microsecond)
+	}
+	if got := strings.Count(buf.String(), "ERROR: boom"); got != 50 {
+		t.Errorf("expected all 50 errors, got %d", got)
+	}
+	if strings.Contains(buf.String(), lm.SampleRateKey) {
+		t.Errorf("expected errors not to be annotated, got %q", buf.String())
+	}
+}
@@ -70,6 +70,23 @@ func BenchmarkInfoWithFields(b *testing.B) {
 	})
 }
 
+// BenchmarkInfoWithTypedFields tests logging with typed fields, the unboxed
+// counterpart to BenchmarkInfoWithFields
+func BenchmarkInfoWithTypedFields(b *testing.B) {
+	logger := newTestLogger(lx.LevelDebug)
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			logger.FieldSet([]lx.Field{
+				lx.String("user", "alice"),
+				lx.String("action", "login"),
+				lx.Int("duration_ms", 42),
+			}).Info("user action")
+		}
+	})
+}
+
 // BenchmarkInfof tests formatted logging
 func BenchmarkInfof(b *testing.B) {
 	logger := newTestLogger(lx.LevelDebug)
@@ -0,0 +1,232 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/olekukonko/ll"
+	"github.com/olekukonko/ll/lh"
+	"github.com/olekukonko/ll/lx"
+)
+
+// tracedError carries the stack trace of where it was created.
+type tracedError struct {
+	msg string
+	pcs []uintptr
+}
+
+func newTracedError(msg string) *tracedError {
+	pcs := make([]uintptr, 8)
+	n := runtime.Callers(2, pcs)
+	return &tracedError{msg: msg, pcs: pcs[:n]}
+}
+
+func (e *tracedError) Error() string         { return e.msg }
+func (e *tracedError) StackTrace() []uintptr { return e.pcs }
+
+// errorCapture records the Error attached to each entry.
+type errorCapture struct {
+	errs []error
+}
+
+func (c *errorCapture) Handle(e *lx.Entry) error {
+	c.errs = append(c.errs, e.Error)
+	return nil
+}
+
+// TestEntryError_Populated verifies that Err, IfErr and FieldBuilder.Err attach errors to entries.
+func TestEntryError_Populated(t *testing.T) {
+	boom := errors.New("boom")
+	other := errors.New("other")
+	capture := &errorCapture{}
+	logger := ll.New("test").Enable().Handler(capture)
+
+	logger.Err(boom)
+	logger.IfErr(boom).Error("failed")
+	logger.IfErr(nil).Error("skipped")
+	logger.IfErrAny(nil, other).Warn("one failed")
+	logger.Fields("k", "v").Err(boom, other).Info("after")
+	logger.Info("plain")
+
+	if len(capture.errs) != 6 {
+		t.Fatalf("expected 6 entries, got %d", len(capture.errs))
+	}
+	if capture.errs[0] != boom || capture.errs[1] != boom || capture.errs[2] != other {
+		t.Errorf("expected boom, boom, other, got %v", capture.errs[:3])
+	}
+	for _, i := range []int{3, 4} {
+		if !errors.Is(capture.errs[i], boom) || !errors.Is(capture.errs[i], other) {
+			t.Errorf("entry %d: expected joined error, got %v", i, capture.errs[i])
+		}
+	}
+	if capture.errs[5] != nil {
+		t.Errorf("expected no error on plain entry, got %v", capture.errs[5])
+	}
+}
+
+// TestEntryError_NoField verifies that Err attaches errors without an "error" field and
+// leaves the logger's context unchanged.
+func TestEntryError_NoField(t *testing.T) {
+	rec := &entryRecorder{}
+	logger := ll.New("test").Enable().Handler(rec)
+
+	logger.Err(errors.New("e1"))
+	logger.Info("later")
+	logger.Fields("k", "v").Err(errors.New("e2")).Info("after")
+	if len(rec.entries) != 4 {
+		t.Fatalf("expected 4 entries, got %d", len(rec.entries))
+	}
+	for i, e := range rec.entries {
+		if _, ok := e.Fields.Get("error"); ok {
+			t.Errorf("entry %d: expected no error field, got %v", i, e.Fields)
+		}
+	}
+	if later := rec.entries[1]; later.Error != nil || len(later.Fields) != 0 {
+		t.Errorf("expected Err not to modify the logger's context, got %v %v", later.Error, later.Fields)
+	}
+	if after := rec.entries[3]; after.Error == nil || fieldString(after, "k") != "v" {
+		t.Errorf("expected the chained entry to carry the error and fields, got %v %v", after.Error, after.Fields)
+	}
+}
+
+// TestEntryError_JSON verifies the structured cause chain in JSON output.
+func TestEntryError_JSON(t *testing.T) {
+	root := newTracedError("disk full")
+	err := fmt.Errorf("save: %w", errors.Join(root, errors.New("retry exhausted")))
+
+	buf := &bytes.Buffer{}
+	logger := ll.New("test").Enable().Handler(lh.NewJSONHandler(buf))
+	logger.IfErr(err).Error("write failed")
+
+	var out lh.JsonOutput
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("invalid JSON %s: %v", buf.String(), err)
+	}
+	info := out.Error
+	if info == nil || info.Type != "*fmt.wrapError" || len(info.Causes) != 1 {
+		t.Fatalf("unexpected error info: %+v", info)
+	}
+	joined := info.Causes[0]
+	if joined.Type != "*errors.joinError" || len(joined.Causes) != 2 {
+		t.Fatalf("expected joined causes, got %+v", joined)
+	}
+	traced := joined.Causes[0]
+	if traced.Message != "disk full" || traced.Type != "*tests.tracedError" || len(traced.Stack) == 0 {
+		t.Fatalf("expected traced cause with stack, got %+v", traced)
+	}
+	if !strings.HasSuffix(traced.Stack[0].Function, "TestEntryError_JSON") {
+		t.Errorf("expected stack to start at the test, got %+v", traced.Stack[0])
+	}
+
+	buf.Reset()
+	logger.Info("no error")
+	if strings.Contains(buf.String(), `"error"`) {
+		t.Errorf("expected no error key without an error, got %s", buf.String())
+	}
+}
+
+// TestEntryError_Colorized verifies the indented cause chain in colorized output.
+func TestEntryError_Colorized(t *testing.T) {
+	err := fmt.Errorf("load config: %w", newTracedError("not found"))
+
+	buf := &bytes.Buffer{}
+	logger := ll.New("test").Enable().Handler(lh.NewColorizedHandler(buf, lh.WithColorNone()))
+	logger.Err(err)
+
+	out := buf.String()
+	for _, want := range []string{
+		"[error]",
+		"┌─ load config: not found <*fmt.wrapError>",
+		"├─ not found <*tests.tracedError>",
+		"│   at github.com/olekukonko/ll/tests.TestEntryError_Colorized",
+		"└",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output:\n%s", want, out)
+		}
+	}
+}
+
+// pcFrame and pcStack mirror github.com/pkg/errors' Frame and StackTrace types.
+type (
+	pcFrame uintptr
+	pcStack []pcFrame
+)
+
+// pkgError carries its stack in the pkg/errors shape.
+type pkgError struct{ stack pcStack }
+
+func (e *pkgError) Error() string { return "pkg" }
+func (e *pkgError) StackTrace() pcStack {
+	return e.stack
+}
+
+// framesError carries its stack as resolved runtime frames.
+type framesError struct{ frames []runtime.Frame }
+
+func (e *framesError) Error() string               { return "frames" }
+func (e *framesError) StackTrace() []runtime.Frame { return e.frames }
+
+// formattedError only exposes its stack through %+v.
+type formattedError struct{}
+
+func (e *formattedError) Error() string { return "formatted" }
+func (e *formattedError) Format(s fmt.State, verb rune) {
+	fmt.Fprint(s, "formatted")
+	if s.Flag('+') {
+		fmt.Fprint(s, "\nmain.load\n\t/src/app/load.go:12\nmain.main\n\t/src/app/main.go:7 +0x1d")
+	}
+}
+
+// messageError annotates its cause like pkg/errors' withMessage: its %+v output
+// includes the stack of the cause.
+type messageError struct {
+	msg   string
+	cause error
+}
+
+func (e *messageError) Error() string { return e.msg + ": " + e.cause.Error() }
+func (e *messageError) Unwrap() error { return e.cause }
+func (e *messageError) Format(s fmt.State, verb rune) {
+	if s.Flag('+') {
+		fmt.Fprintf(s, "%+v\n%s", e.cause, e.msg)
+		return
+	}
+	fmt.Fprint(s, e.Error())
+}
+
+// TestErrorStack_Shapes verifies that stacks are found in the common conventions.
+func TestErrorStack_Shapes(t *testing.T) {
+	pcs := make([]uintptr, 4)
+	pcs = pcs[:runtime.Callers(1, pcs)]
+	var stack pcStack
+	for _, pc := range pcs {
+		stack = append(stack, pcFrame(pc))
+	}
+
+	if got := lx.ErrorStack(&pkgError{stack: stack}); len(got) == 0 || !strings.HasSuffix(got[0].Function, "TestErrorStack_Shapes") {
+		t.Errorf("pkg/errors shape: unexpected stack %+v", got)
+	}
+	frames := &framesError{frames: []runtime.Frame{{Function: "main.run", File: "main.go", Line: 3}}}
+	if got := lx.ErrorStack(frames); len(got) != 1 || got[0].Function != "main.run" || got[0].Line != 3 {
+		t.Errorf("runtime.Frame shape: unexpected stack %+v", got)
+	}
+	got := lx.ErrorStack(&formattedError{})
+	want := []lx.Frame{{Function: "main.load", File: "/src/app/load.go", Line: 12}, {Function: "main.main", File: "/src/app/main.go", Line: 7}}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("%%+v shape: expected %+v, got %+v", want, got)
+	}
+	if got := lx.ErrorStack(fmt.Errorf("plain")); got != nil {
+		t.Errorf("expected no stack for a plain error, got %+v", got)
+	}
+
+	info := lx.DescribeError(&messageError{msg: "load", cause: &formattedError{}})
+	if info.Stack != nil || len(info.Causes) != 1 || len(info.Causes[0].Stack) != 2 {
+		t.Errorf("expected the stack on the cause only, got %+v and %+v", info.Stack, info.Causes)
+	}
+}
@@ -0,0 +1,147 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/olekukonko/ll"
+	"github.com/olekukonko/ll/lh"
+	"github.com/olekukonko/ll/lx"
+)
+
+// TestTypedFields_Interface verifies that typed fields box back to their natural Go types.
+func TestTypedFields_Interface(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 30, 0, 500, time.UTC)
+	boom := errors.New("boom")
+	tests := []struct {
+		field lx.Field
+		want  interface{}
+	}{
+		{lx.String("s", "hi"), "hi"},
+		{lx.Int("i", -3), int64(-3)},
+		{lx.Int64("i64", 42), int64(42)},
+		{lx.Uint64("u", 7), uint64(7)},
+		{lx.Float64("f", 1.5), 1.5},
+		{lx.Bool("b", true), true},
+		{lx.Duration("d", 1500*time.Millisecond), 1500 * time.Millisecond},
+		{lx.Err("err", boom), boom},
+		{lx.Any("a", []int{1}), nil}, // compared separately
+	}
+	for _, tt := range tests {
+		if tt.field.Key == "a" {
+			if v, ok := tt.field.Interface().([]int); !ok || v[0] != 1 {
+				t.Errorf("Any: unexpected value %v", tt.field.Interface())
+			}
+			continue
+		}
+		if got := tt.field.Interface(); got != tt.want {
+			t.Errorf("%s: expected %v (%T), got %v (%T)", tt.field.Key, tt.want, tt.want, got, got)
+		}
+	}
+
+	got, ok := lx.Time("t", now).Interface().(time.Time)
+	if !ok || !got.Equal(now) || got.Location() != time.UTC {
+		t.Errorf("Time: expected %v, got %v", now, got)
+	}
+	if v, ok := (lx.Fields{lx.Int("n", 1)}).Get("n"); !ok || v != int64(1) {
+		t.Errorf("Fields.Get: expected int64(1), got %v", v)
+	}
+}
+
+// TestTypedFields_Text verifies text and colorized output for typed fields.
+func TestTypedFields_Text(t *testing.T) {
+	fields := []lx.Field{
+		lx.String("user", "alice"),
+		lx.Int("n", 42),
+		lx.Uint64("u", 7),
+		lx.Float64("f", 1.5),
+		lx.Bool("ok", true),
+		lx.Duration("d", 1500*time.Millisecond),
+		lx.Err("err", errors.New("boom")),
+		lx.Err("none", nil),
+	}
+	want := "[user=alice n=42 u=7 f=1.5 ok=true d=1.5s err=boom none=nil]"
+
+	buf := &bytes.Buffer{}
+	logger := ll.New("test").Enable().Handler(lh.NewTextHandler(buf))
+	logger.FieldSet(fields).Info("typed")
+	if !strings.Contains(buf.String(), want) {
+		t.Errorf("Expected %q to contain %q", buf.String(), want)
+	}
+
+	buf.Reset()
+	logger.Handler(lh.NewColorizedHandler(buf, lh.WithColorNone()))
+	logger.FieldSet(fields).Info("typed")
+	if !strings.Contains(buf.String(), want) {
+		t.Errorf("Expected colorized %q to contain %q", buf.String(), want)
+	}
+
+	// Typed and boxed times render identically.
+	now := time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC)
+	buf.Reset()
+	logger.Handler(lh.NewTextHandler(buf))
+	logger.FieldSet([]lx.Field{lx.Time("t", now)}).Info("typed")
+	typed := buf.String()
+	buf.Reset()
+	logger.Fields("t", now).Info("typed")
+	if typed != buf.String() {
+		t.Errorf("Expected typed time %q to match boxed %q", typed, buf.String())
+	}
+}
+
+// TestTypedFields_Colorized verifies that colored typed fields render like their boxed values.
+func TestTypedFields_Colorized(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC)
+	buf := &bytes.Buffer{}
+	logger := ll.New("test").Enable().Handler(lh.NewColorizedHandler(buf, lh.WithColorField(true)))
+
+	logger.FieldSet([]lx.Field{
+		lx.String("user", "alice"),
+		lx.Int("n", 42),
+		lx.Float64("f", 1.5),
+		lx.Bool("ok", true),
+		lx.Duration("d", 1500*time.Millisecond),
+		lx.Time("t", now),
+	}).Info("typed")
+	typed := buf.String()
+	buf.Reset()
+	logger.Fields("user", "alice", "n", 42, "f", 1.5, "ok", true, "d", 1500*time.Millisecond, "t", now).Info("typed")
+	if typed != buf.String() {
+		t.Errorf("Expected typed output %q to match boxed %q", typed, buf.String())
+	}
+}
+
+// TestTypedFields_Structured verifies JSON and slog output for typed fields.
+func TestTypedFields_Structured(t *testing.T) {
+	fields := []lx.Field{
+		lx.String("user", "alice"),
+		lx.Int64("n", 42),
+		lx.Bool("ok", true),
+		lx.Duration("d", time.Second),
+	}
+
+	buf := &bytes.Buffer{}
+	logger := ll.New("test").Enable().Handler(lh.NewJSONHandler(buf))
+	logger.FieldSet(fields).Info("typed")
+	var data lh.JsonOutput
+	if err := json.Unmarshal(buf.Bytes(), &data); err != nil {
+		t.Fatalf("Expected valid JSON, got %v: %s", err, buf.String())
+	}
+	if data.Fields["user"] != "alice" || data.Fields["n"] != float64(42) || data.Fields["ok"] != true || data.Fields["d"] != float64(time.Second) {
+		t.Errorf("Unexpected JSON fields: %v", data.Fields)
+	}
+
+	buf.Reset()
+	logger.Handler(lh.NewSlogHandler(slog.NewJSONHandler(buf, nil)))
+	logger.FieldSet(fields).Info("typed")
+	for _, want := range []string{`"user":"alice"`, `"n":42`, `"ok":true`, `"d":1000000000`} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("Expected slog output %q to contain %q", buf.String(), want)
+		}
+	}
+}
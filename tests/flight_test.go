@@ -0,0 +1,121 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/olekukonko/ll"
+	"github.com/olekukonko/ll/lh"
+	"github.com/olekukonko/ll/lm"
+	"github.com/olekukonko/ll/lx"
+)
+
+// TestLevelFilter verifies that Level drops entries less severe than the minimum level.
+func TestLevelFilter(t *testing.T) {
+	rec := &entryRecorder{}
+	logger := ll.New("app").Enable().Handler(rec).Level(lx.LevelWarn)
+	logger.Debug("debug")
+	logger.Info("info")
+	logger.Warn("warn")
+	logger.Error("error")
+	logger.Dump("raw") // Dumps have no severity and are never filtered
+
+	var got []string
+	for _, e := range rec.entries {
+		if e.Class != lx.ClassDump {
+			got = append(got, e.Message)
+		}
+	}
+	if len(got) != 2 || got[0] != "warn" || got[1] != "error" {
+		t.Errorf("expected warn and error, got %v", got)
+	}
+}
+
+// TestFlightRecorder verifies that below-level entries reach only the recorder and are
+// replayed before an error.
+func TestFlightRecorder(t *testing.T) {
+	rec := &entryRecorder{}
+	flight := lh.NewFlightRecorder()
+	logger := ll.New("app").Enable().Level(lx.LevelInfo).Handler(lh.Pipe(rec, flight.Wrap)).Recorder(flight)
+	db := logger.Namespace("db")
+
+	db.Debug("cache miss")
+	db.Fields("rows", 0).Debug("query returned nothing")
+	logger.Debug("unrelated namespace")
+	db.Info("retrying")
+	if len(rec.entries) != 1 || rec.entries[0].Message != "retrying" {
+		t.Fatalf("expected only the Info entry to be written, got %d entries", len(rec.entries))
+	}
+
+	db.Error("query failed")
+	var got []string
+	for _, e := range rec.entries {
+		if v, _ := e.Fields.Get(lh.BackfillKey); v == true {
+			got = append(got, "backfill:"+e.Message)
+			continue
+		}
+		got = append(got, e.Message)
+	}
+	want := []string{"retrying", "backfill:cache miss", "backfill:query returned nothing", "query failed"}
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("entry %d: expected %q, got %q", i, want[i], got[i])
+		}
+	}
+	if rows, ok := rec.entries[2].Fields.Get("rows"); !ok || rows != 0 {
+		t.Errorf("expected replayed entry to keep its fields, got rows=%v", rows)
+	}
+
+	flight.Reset()
+	logger.Recorder(nil)
+	logger.Debug("dropped")
+	logger.Error("again")
+	if last := rec.entries[len(rec.entries)-1]; last.Message != "again" || len(rec.entries) != 5 {
+		t.Errorf("expected no context after detaching the recorder, got %d entries", len(rec.entries))
+	}
+}
+
+// TestFlightRecorder_Isolation verifies that recorded entries skip gates, lazy resolution
+// and timings but not redaction, and that CanLog still reports the level filter.
+func TestFlightRecorder_Isolation(t *testing.T) {
+	rec := &entryRecorder{}
+	flight := lh.NewFlightRecorder()
+	logger := ll.New("app").Enable().Level(lx.LevelInfo).Handler(lh.Pipe(rec, flight.Wrap)).Recorder(flight)
+	metrics := lm.NewMetrics()
+	logger.Use(metrics)
+	timings := ll.NewTimings(logger, 0)
+	logger.Timings(timings)
+
+	if logger.CanLog(lx.LevelDebug) {
+		t.Error("expected CanLog(Debug) to be false on an Info logger with a recorder")
+	}
+	resolved := false
+	logger.Fields("payload", lx.Lazy(func() any {
+		resolved = true
+		return "big"
+	})).Debug("request")
+	logger.Since().Debug("query")
+	if n := len(metrics.Snapshot().Entries); n != 0 || resolved || len(timings.Snapshot()) != 0 {
+		t.Errorf("expected recorded entries to skip gates, lazy values and timings: metrics=%d resolved=%v timings=%d",
+			n, resolved, len(timings.Snapshot()))
+	}
+
+	// Redaction resolves the values it inspects, so it is added after the lazy check
+	logger.Use(lm.NewRedact(lm.RedactKey(lm.RedactDrop, "password"), lm.RedactEmails(lm.RedactMask)))
+	logger.Fields("password", "hunter2").Debug("login bob@example.com")
+
+	logger.Error("failed")
+	if len(rec.entries) != 4 || len(metrics.Snapshot().Entries) != 1 {
+		t.Fatalf("expected 3 replayed entries and the error, got %d (metrics %v)", len(rec.entries), metrics.Snapshot().Entries)
+	}
+	if !resolved || fieldString(rec.entries[0], "payload") != "big" {
+		t.Errorf("expected the lazy value to be resolved when replayed, got %v", rec.entries[0].Fields)
+	}
+	login := rec.entries[2]
+	if _, ok := login.Fields.Get("password"); ok || strings.Contains(login.Message, "bob@example.com") {
+		t.Errorf("expected the recorded entry to be redacted, got %q %v", login.Message, login.Fields)
+	}
+}
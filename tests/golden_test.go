@@ -0,0 +1,38 @@
+package tests
+
+import (
+	"errors"
+	"flag"
+	"testing"
+	"time"
+
+	"github.com/olekukonko/ll"
+	"github.com/olekukonko/ll/lltest"
+)
+
+// The test package owns the -update flag; defining it must not clash with lltest.
+func init() {
+	flag.BoolVar(&lltest.Update, "update", false, "update golden files")
+}
+
+// TestGolden_Formats snapshots the same entries as rendered by each built-in handler.
+func TestGolden_Formats(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		format lltest.Format
+	}{
+		{"text", lltest.FormatText},
+		{"json", lltest.FormatJSON},
+		{"color", lltest.FormatColor},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := lltest.NewRecorder()
+			logger := ll.New("app").Enable().Handler(rec)
+			logger.Fields("user", "alice", "attempt", 2).Info("login")
+			logger.Namespace("db").Fields("took", 1500*time.Microsecond).Warn("slow query")
+			logger.Fields("at", time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC)).Error("scheduled job failed")
+			logger.Fields().Err(errors.New("connection reset")).Error("request failed")
+			rec.Snapshot(t, "formats_"+tc.name, tc.format)
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/olekukonko/ll"
+	"github.com/olekukonko/ll/lh"
+	"github.com/olekukonko/ll/lx"
+)
+
+// TestGroup_Text verifies that groups flatten into dotted keys in text output.
+func TestGroup_Text(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logger := ll.New("test").Enable().Handler(lh.NewTextHandler(buf))
+	logger.Fields("user", "alice").
+		Group("http", "method", "GET", lx.Int("status", 200), lx.Group("req", lx.String("id", "r1"))).
+		Info("served")
+
+	want := "[user=alice http.method=GET http.status=200 http.req.id=r1]"
+	if !strings.Contains(buf.String(), want) {
+		t.Errorf("Expected %q to contain %q", buf.String(), want)
+	}
+
+	buf.Reset()
+	logger.Fields().Group("bad", "k", "v", 42).Info("uneven")
+	if !strings.Contains(buf.String(), "bad.k=v bad.error=uneven key-value pairs in Group: [42]") {
+		t.Errorf("Expected uneven pair error inside group, got %q", buf.String())
+	}
+}
+
+// TestGroup_JSON verifies that groups nest in JSON output in field order.
+func TestGroup_JSON(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logger := ll.New("test").Enable().Handler(lh.NewJSONHandler(buf))
+	logger.Fields().Group("http", "method", "GET", "status", 200).Merge("method", "top").Info("served")
+
+	if !strings.Contains(buf.String(), `"fields":{"http":{"method":"GET","status":200},"method":"top"}`) {
+		t.Errorf("Expected nested group in %s", buf.String())
+	}
+	var data lh.JsonOutput
+	if err := json.Unmarshal(buf.Bytes(), &data); err != nil {
+		t.Fatalf("Expected valid JSON, got %v", err)
+	}
+}
+
+// TestGroup_Slog verifies that groups become slog groups.
+func TestGroup_Slog(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logger := ll.New("test").Enable().Handler(lh.NewSlogHandler(slog.NewJSONHandler(buf, nil)))
+	logger.Fields().Group("http", "method", "GET", "status", 200).Info("served")
+
+	if !strings.Contains(buf.String(), `"http":{"method":"GET","status":200}`) {
+		t.Errorf("Expected slog group in %s", buf.String())
+	}
+}
+
+// TestGroup_Translate verifies that key mappings only apply to top-level keys.
+func TestGroup_Translate(t *testing.T) {
+	fields := lx.Fields{lx.Group("http", lx.String("method", "GET")), {Key: "method", Value: "rpc"}}
+	translated := fields.Translate(map[string]string{"method": "rpc_method"})
+
+	if v, ok := translated.Get("rpc_method"); !ok || v != "rpc" {
+		t.Errorf("Expected top-level key to be mapped, got %v", translated)
+	}
+	m := translated.Map()
+	if http, ok := m["http"].(map[string]interface{}); !ok || http["method"] != "GET" {
+		t.Errorf("Expected group to be left intact, got %v", m)
+	}
+}
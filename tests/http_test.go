@@ -0,0 +1,222 @@
+package tests
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/olekukonko/ll"
+	"github.com/olekukonko/ll/lh"
+	"github.com/olekukonko/ll/lm"
+	"github.com/olekukonko/ll/lx"
+)
+
+// TestHTTPMiddleware verifies the fields and level of request entries.
+func TestHTTPMiddleware(t *testing.T) {
+	rec := &entryRecorder{}
+	logger := ll.New("http").Enable().Handler(rec)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/users", func(w http.ResponseWriter, r *http.Request) {
+		io.ReadAll(r.Body)
+		w.Write([]byte("hello"))
+	})
+	mux.HandleFunc("/fail", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "boom", http.StatusInternalServerError)
+	})
+	handler := ll.HTTPMiddleware(logger,
+		ll.WithHTTPRoute(func(r *http.Request) string { return "route:" + r.URL.Path }),
+	)(mux)
+
+	req := httptest.NewRequest("POST", "/users?id=7", strings.NewReader("payload"))
+	req.Header.Set("User-Agent", "tester")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/fail", nil))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/missing", nil))
+
+	if len(rec.entries) != 3 {
+		t.Fatalf("expected 3 entries, got %d", len(rec.entries))
+	}
+	e := rec.entries[0]
+	if e.Message != "POST /users" || e.Level != lx.LevelInfo {
+		t.Errorf("unexpected entry: %q at %v", e.Message, e.Level)
+	}
+	for key, want := range map[string]any{
+		"method":     "POST",
+		"path":       "/users",
+		"query":      "id=7",
+		"route":      "route:/users",
+		"status":     int64(200),
+		"bytes_in":   int64(7),
+		"bytes_out":  int64(5),
+		"user_agent": "tester",
+	} {
+		if got, _ := e.Fields.Get(key); got != want {
+			t.Errorf("field %s: expected %v (%T), got %v (%T)", key, want, want, got, got)
+		}
+	}
+	if rec.entries[1].Level != lx.LevelError {
+		t.Errorf("expected 5xx at Error, got %v", rec.entries[1].Level)
+	}
+	if rec.entries[2].Level != lx.LevelWarn {
+		t.Errorf("expected 4xx at Warn, got %v", rec.entries[2].Level)
+	}
+}
+
+// TestHTTPMiddleware_SkipAndSlow verifies skip lists and slow-request thresholds.
+func TestHTTPMiddleware_SkipAndSlow(t *testing.T) {
+	rec := &entryRecorder{}
+	logger := ll.New("http").Enable().Handler(rec)
+	handler := ll.HTTPMiddleware(logger,
+		ll.WithHTTPSkipPaths("/healthz"),
+		ll.WithHTTPSkip(func(r *http.Request) bool { return r.Method == "OPTIONS" }),
+		ll.WithHTTPSlowThreshold(5*time.Millisecond),
+	)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/slow" {
+			time.Sleep(10 * time.Millisecond)
+		}
+	}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/healthz", nil))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("OPTIONS", "/api", nil))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/slow", nil))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/fast", nil))
+
+	if len(rec.entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(rec.entries))
+	}
+	slow := rec.entries[0]
+	if v, _ := slow.Fields.Get("slow"); slow.Level != lx.LevelWarn || v != true {
+		t.Errorf("expected slow request at Warn with slow=true, got %v %v", slow.Level, v)
+	}
+	if _, ok := rec.entries[1].Fields.Get("slow"); ok || rec.entries[1].Level != lx.LevelInfo {
+		t.Errorf("expected fast request at Info without slow, got %v", rec.entries[1].Level)
+	}
+}
+
+// TestHTTPMiddleware_Suspended verifies that a suspended logger writes no access or
+// transport entries, as with the logging methods.
+func TestHTTPMiddleware_Suspended(t *testing.T) {
+	rec := &entryRecorder{}
+	logger := ll.New("http").Enable().Handler(rec)
+	handler := ll.HTTPMiddleware(logger)(http.NotFoundHandler())
+	srv := httptest.NewServer(handler)
+	defer srv.Close()
+	client := &http.Client{Transport: ll.LogTransport(logger, nil, ll.WithTransportBodies(1024))}
+
+	logger.Suspend()
+	resp, err := client.Get(srv.URL + "/x")
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	resp.Body.Close()
+	if len(rec.entries) != 0 {
+		t.Errorf("expected no entries from a suspended logger, got %d", len(rec.entries))
+	}
+
+	logger.Resume()
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/x", nil))
+	if len(rec.entries) != 1 {
+		t.Errorf("expected the entry once resumed, got %d", len(rec.entries))
+	}
+}
+
+// TestHTTPMiddleware_Trace verifies that request entries carry the trace IDs set by
+// an enclosing TraceMiddleware.
+func TestHTTPMiddleware_Trace(t *testing.T) {
+	rec := &entryRecorder{}
+	logger := ll.New("http").Enable().Handler(rec)
+	handler := ll.TraceMiddleware(logger)(ll.HTTPMiddleware(logger)(http.NotFoundHandler()))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil))
+
+	if len(rec.entries) != 1 {
+		t.Fatalf("expected 1 entry, got %d", len(rec.entries))
+	}
+	if fieldString(rec.entries[0], ll.TraceIDKey) == "" || fieldString(rec.entries[0], ll.SpanIDKey) == "" {
+		t.Errorf("expected trace IDs on request entry, got %v", rec.entries[0].Fields)
+	}
+}
+
+// TestAccessLogHandler verifies Apache Common and Combined output.
+func TestAccessLogHandler(t *testing.T) {
+	var common, combined bytes.Buffer
+	logger := ll.New("http").Enable().Handler(lh.NewMultiHandler(
+		lh.NewAccessLogHandler(&common, lh.AccessCommon),
+		lh.NewAccessLogHandler(&combined, lh.AccessCombined),
+	))
+	handler := ll.HTTPMiddleware(logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/empty" {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.Write([]byte("hello"))
+	}))
+
+	req := httptest.NewRequest("GET", "/users?id=1", nil)
+	req.RemoteAddr = "10.0.0.1:5123"
+	req.Header.Set("User-Agent", `curl "8.0"`)
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("DELETE", "/empty", nil))
+	logger.Info("not a request") // Ignored by the access log handlers
+
+	ts := `\[\d{2}/\w{3}/\d{4}:\d{2}:\d{2}:\d{2} [+-]\d{4}\]`
+	commonRe := regexp.MustCompile(`^10\.0\.0\.1 - - ` + ts + ` "GET /users\?id=1 HTTP/1\.1" 200 5
+192\.0\.2\.1 - - ` + ts + ` "DELETE /empty HTTP/1\.1" 204 -
+$`)
+	if !commonRe.MatchString(common.String()) {
+		t.Errorf("unexpected common output:\n%s", common.String())
+	}
+	combinedRe := regexp.MustCompile(`^10\.0\.0\.1 - - ` + ts + ` "GET /users\?id=1 HTTP/1\.1" 200 5 "-" "curl \\"8\.0\\""
+`)
+	if !combinedRe.MatchString(combined.String()) {
+		t.Errorf("unexpected combined output:\n%s", combined.String())
+	}
+}
+
+// TestAccessLogHandler_Escaping verifies that decoded paths and headers cannot inject
+// quotes or new lines into access log lines.
+func TestAccessLogHandler_Escaping(t *testing.T) {
+	var out bytes.Buffer
+	logger := ll.New("http").Enable().Handler(lh.NewAccessLogHandler(&out, lh.AccessCombined))
+	handler := ll.HTTPMiddleware(logger)(http.NotFoundHandler())
+
+	req := httptest.NewRequest("GET", "/a%22%0Afake?q=%0D", nil)
+	req.Header.Set("User-Agent", "bot\x01\\")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	line := out.String()
+	if strings.Count(line, "\n") != 1 {
+		t.Fatalf("expected a single line, got %q", line)
+	}
+	for _, want := range []string{`"GET /a\"\x0afake?q=%0D HTTP/1.1" 404`, `"bot\x01\\"`} {
+		if !strings.Contains(line, want) {
+			t.Errorf("expected %q in %q", want, line)
+		}
+	}
+}
+
+// TestAccessLogHandler_Untyped verifies that request fields are read whatever their
+// kind, for entries logged with Fields or rewritten by middleware.
+func TestAccessLogHandler_Untyped(t *testing.T) {
+	var out bytes.Buffer
+	logger := ll.New("http").Enable().Handler(lh.NewAccessLogHandler(&out, lh.AccessCommon))
+	logger.Fields("method", "GET", "path", "/x", "proto", "HTTP/1.1", "status", 404,
+		"bytes_out", uint64(12), "duration", time.Second).Info("request")
+
+	redacted := ll.New("http").Enable().Handler(lh.NewAccessLogHandler(&out, lh.AccessCommon))
+	redacted.Use(lm.NewRedact(lm.RedactEmails(lm.RedactMask)))
+	handler := ll.HTTPMiddleware(redacted)(http.NotFoundHandler())
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/x?to=bob@example.com", nil))
+
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	if len(lines) != 2 || !strings.Contains(lines[0], `"GET /x HTTP/1.1" 404 12`) {
+		t.Fatalf("expected the untyped fields to be read, got %q", out.String())
+	}
+	if !strings.Contains(lines[1], `HTTP/1.1" 404`) || strings.Contains(lines[1], "bob@example.com") {
+		t.Errorf("expected the redacted request with its status, got %q", lines[1])
+	}
+}
@@ -0,0 +1,109 @@
+package tests
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/olekukonko/ll"
+	"github.com/olekukonko/ll/lh"
+	"github.com/olekukonko/ll/lx"
+)
+
+// account provides its own log representation, hiding the secret.
+type account struct {
+	ID     string
+	Secret string
+}
+
+func (a account) LogValue() any {
+	return "account:" + a.ID
+}
+
+// TestLazy_SkippedWhenFiltered verifies that lazy values are not computed for filtered entries.
+func TestLazy_SkippedWhenFiltered(t *testing.T) {
+	var calls atomic.Int32
+	payload := lx.Lazy(func() any {
+		calls.Add(1)
+		return "expensive"
+	})
+
+	buf := &bytes.Buffer{}
+	logger := ll.New("test").Enable().Handler(lh.NewTextHandler(buf)).Level(lx.LevelInfo)
+	logger.Fields("payload", payload).Debug("level filtered")
+
+	logger.Use(ll.Middle(func(e *lx.Entry) error {
+		if e.Message == "drop" {
+			return errors.New("dropped")
+		}
+		return nil
+	}))
+	logger.Fields("payload", payload).Info("drop")
+
+	if got := calls.Load(); got != 0 {
+		t.Fatalf("expected no evaluations for filtered entries, got %d", got)
+	}
+
+	logger.Fields("payload", payload).Info("kept")
+	if got := calls.Load(); got != 1 {
+		t.Errorf("expected 1 evaluation, got %d", got)
+	}
+	if !strings.Contains(buf.String(), "[payload=expensive]") {
+		t.Errorf("expected resolved payload in %q", buf.String())
+	}
+}
+
+// TestLazy_ResolvedOnceAcrossHandlers verifies that fan-out handlers share one evaluation.
+func TestLazy_ResolvedOnceAcrossHandlers(t *testing.T) {
+	var calls atomic.Int32
+	text, js := &bytes.Buffer{}, &bytes.Buffer{}
+	logger := ll.New("test").Enable().Handler(lh.NewMultiHandler(lh.NewTextHandler(text), lh.NewJSONHandler(js)))
+
+	logger.Fields("n", lx.Lazy(func() any {
+		calls.Add(1)
+		return 42
+	})).Info("fan out")
+
+	if got := calls.Load(); got != 1 {
+		t.Errorf("expected 1 evaluation, got %d", got)
+	}
+	if !strings.Contains(text.String(), "[n=42]") || !strings.Contains(js.String(), `"n":42`) {
+		t.Errorf("expected resolved value in both outputs, got %q and %q", text.String(), js.String())
+	}
+}
+
+// TestLogValuer verifies that handlers and Inspect use a type's own log representation.
+func TestLogValuer(t *testing.T) {
+	acct := account{ID: "42", Secret: "hunter2"}
+
+	buf := &bytes.Buffer{}
+	logger := ll.New("test").Enable().Handler(lh.NewTextHandler(buf))
+	logger.Fields("acct", acct).Info("login")
+	logger.Inspect(acct)
+
+	out := buf.String()
+	if strings.Contains(out, "hunter2") {
+		t.Errorf("expected secret to stay hidden, got %q", out)
+	}
+	if strings.Count(out, "account:42") != 2 {
+		t.Errorf("expected LogValue representation in field and inspect output, got %q", out)
+	}
+
+	// Handlers resolve LogValuers on entries that did not pass through a logger.
+	buf.Reset()
+	h := lh.NewJSONHandler(buf)
+	h.Handle(&lx.Entry{Fields: lx.Fields{{Key: "acct", Value: acct}}})
+	if !strings.Contains(buf.String(), `"acct":"account:42"`) {
+		t.Errorf("expected JSON handler to resolve LogValuer, got %q", buf.String())
+	}
+}
+
+// TestLazy_Panic verifies that a panicking lazy value is reported instead of crashing.
+func TestLazy_Panic(t *testing.T) {
+	got := lx.Resolve(lx.Lazy(func() any { panic("boom") }))
+	if err, ok := got.(error); !ok || !strings.Contains(err.Error(), "boom") {
+		t.Errorf("expected recovered panic as error, got %v", got)
+	}
+}
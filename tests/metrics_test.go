@@ -0,0 +1,79 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"expvar"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/olekukonko/ll"
+	"github.com/olekukonko/ll/lh"
+	"github.com/olekukonko/ll/lm"
+	"github.com/olekukonko/ll/lx"
+)
+
+// failingHandler rejects every entry.
+type failingHandler struct{}
+
+func (failingHandler) Handle(e *lx.Entry) error { return errors.New("sink down") }
+
+// TestMetrics_Prometheus verifies entry, drop and handler error counters in the
+// Prometheus text format.
+func TestMetrics_Prometheus(t *testing.T) {
+	metrics := lm.NewMetrics()
+	dedup := lh.NewDedup(lh.NewTextHandler(&bytes.Buffer{}), time.Minute)
+	defer dedup.Close()
+	metrics.Observe("dedup", dedup.Dropped)
+
+	logger := ll.New("app").Enable().Handler(lh.NewMultiHandler(
+		dedup,
+		lh.Pipe(failingHandler{}, metrics.Wrap("remote")),
+	))
+	logger.Use(metrics)
+	logger.Use(metrics.Track("rate", lm.NewRateLimiter(lx.LevelInfo, 2, time.Minute)))
+
+	for i := 0; i < 4; i++ {
+		logger.Info("same")
+	}
+	logger.Namespace("db").Warn("slow query")
+	metrics.Drop("buffered")
+
+	rec := httptest.NewRecorder()
+	metrics.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	out := rec.Body.String()
+	for _, want := range []string{
+		"# TYPE ll_entries_total counter",
+		`ll_entries_total{level="INFO",namespace="app",class="TEXT"} 4`,
+		`ll_entries_total{level="WARN",namespace="app/db",class="TEXT"} 1`,
+		`ll_dropped_total{source="buffered"} 1`,
+		`ll_dropped_total{source="dedup"} 1`,
+		`ll_dropped_total{source="rate"} 2`,
+		`ll_handler_errors_total{sink="remote"} 3`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output:\n%s", want, out)
+		}
+	}
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain; version=0.0.4") {
+		t.Errorf("unexpected content type %q", ct)
+	}
+}
+
+// TestMetrics_Expvar verifies that the snapshot is published through expvar.
+func TestMetrics_Expvar(t *testing.T) {
+	metrics := lm.NewMetrics()
+	metrics.Publish("ll_test_metrics")
+	metrics.Handle(&lx.Entry{Level: lx.LevelError, Namespace: "app"})
+
+	var snap lm.MetricsSnapshot
+	if err := json.Unmarshal([]byte(expvar.Get("ll_test_metrics").String()), &snap); err != nil {
+		t.Fatalf("invalid expvar JSON: %v", err)
+	}
+	if len(snap.Entries) != 1 || snap.Entries[0].Level != "ERROR" || snap.Entries[0].Count != 1 {
+		t.Errorf("unexpected snapshot: %+v", snap)
+	}
+}
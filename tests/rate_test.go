@@ -0,0 +1,92 @@
+package tests
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/olekukonko/ll"
+	"github.com/olekukonko/ll/lh"
+	"github.com/olekukonko/ll/lm"
+	"github.com/olekukonko/ll/lx"
+)
+
+// TestKeyedRateLimiter_Middleware verifies keyed limits and the summary fields attached
+// to the entry that reopens a key.
+func TestKeyedRateLimiter_Middleware(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logger := ll.New("app").Enable().Handler(lh.NewTextHandler(buf))
+	logger.Use(lm.NewKeyedRateLimiter(lm.RateByField("user"), 1, 40*time.Millisecond))
+
+	for i := 0; i < 5; i++ {
+		logger.Fields("user", "alice").Info("login failed")
+		logger.Fields("user", "bob").Info("login failed")
+	}
+	if got := strings.Count(buf.String(), "login failed"); got != 2 {
+		t.Fatalf("expected one entry per user, got %d in %q", got, buf.String())
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	buf.Reset()
+	logger.Fields("user", "alice").Info("login failed")
+	for _, want := range []string{"rate_limit_key=alice", "suppressed=4"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("expected %q in %q", want, buf.String())
+		}
+	}
+}
+
+// TestKeyedRateLimiter_Callsite verifies that each logging call gets its own budget.
+func TestKeyedRateLimiter_Callsite(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logger := ll.New("app").Enable().Handler(lh.NewTextHandler(buf))
+	logger.Use(lm.NewKeyedRateLimiter(lm.RateByCallsite, 1, time.Minute))
+
+	for i := 0; i < 3; i++ {
+		logger.Infof("first site %d", i)
+		logger.Infof("second site %d", i)
+	}
+	if got := strings.Count(buf.String(), "site"); got != 2 {
+		t.Errorf("expected one entry per callsite, got %d in %q", got, buf.String())
+	}
+}
+
+// TestKeyedRateLimiter_MaxKeys verifies that key cardinality is bounded.
+func TestKeyedRateLimiter_MaxKeys(t *testing.T) {
+	limiter := lm.NewKeyedRateLimiter(lm.RateByMessage, 1, time.Minute, lm.WithRateMaxKeys(32))
+
+	allowed := 0
+	for i := 0; i < 1000; i++ {
+		if limiter.Handle(&lx.Entry{Message: fmt.Sprintf("msg %d", i)}) == nil {
+			allowed++
+		}
+	}
+	// One key per shard plus one overflow bucket per shard, each allowing one entry.
+	if allowed > 64 {
+		t.Errorf("expected new keys to share overflow buckets, got %d allowed", allowed)
+	}
+
+	total := uint64(0)
+	for _, summary := range limiter.Drain() {
+		v, _ := summary.Fields.Get(lm.SuppressedKey)
+		total += v.(uint64)
+	}
+	if total != uint64(1000-allowed) {
+		t.Errorf("expected %d suppressed in summaries, got %d", 1000-allowed, total)
+	}
+}
+
+// TestKeyedRateLimiter_NoInterval verifies that a zero interval disables the limit.
+func TestKeyedRateLimiter_NoInterval(t *testing.T) {
+	limiter := lm.NewKeyedRateLimiter(lm.RateByMessage, 1, 0)
+	for i := 0; i < 10; i++ {
+		if summary, allowed := limiter.Take(&lx.Entry{Message: "same"}); !allowed || summary != nil {
+			t.Fatalf("entry %d: expected no limit, got allowed=%v summary=%v", i, allowed, summary)
+		}
+	}
+	if len(limiter.Drain()) != 0 {
+		t.Error("expected nothing suppressed")
+	}
+}
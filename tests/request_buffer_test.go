@@ -0,0 +1,128 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/olekukonko/ll"
+	"github.com/olekukonko/ll/lh"
+	"github.com/olekukonko/ll/lx"
+)
+
+// TestRequestBuffer_Discard verifies that a successful, fast unit of work writes nothing.
+func TestRequestBuffer_Discard(t *testing.T) {
+	rec := &entryRecorder{}
+	logger := ll.New("app").Enable().Handler(rec).Level(lx.LevelInfo)
+
+	ctx, buf := logger.Buffer(context.Background())
+	buf.Logger().Debug("loading cart")
+	ll.FromContext(ctx).Info("cart loaded")
+	if buf.Len() != 2 {
+		t.Fatalf("expected 2 buffered entries, got %d", buf.Len())
+	}
+	if buf.End(nil) {
+		t.Error("expected a successful unit of work not to be flushed")
+	}
+	if len(rec.entries) != 0 {
+		t.Errorf("expected no entries to be written, got %d", len(rec.entries))
+	}
+}
+
+// TestRequestBuffer_Flush verifies that an error or a failed entry flushes the buffered
+// entries in order with their original timestamps.
+func TestRequestBuffer_Flush(t *testing.T) {
+	rec := &entryRecorder{}
+	logger := ll.New("app").Enable().Handler(rec).Level(lx.LevelInfo)
+
+	_, buf := logger.Buffer(context.Background())
+	buf.Logger().Fields("sku", "A1").Debug("loading cart")
+	logged := time.Now()
+	time.Sleep(5 * time.Millisecond)
+	if !buf.End(errors.New("payment declined")) {
+		t.Fatal("expected a failed unit of work to be flushed")
+	}
+	if len(rec.entries) != 1 || rec.entries[0].Message != "loading cart" {
+		t.Fatalf("expected the buffered entry to be written, got %d entries", len(rec.entries))
+	}
+	if e := rec.entries[0]; e.Timestamp.After(logged) || fieldString(e, "sku") != "A1" {
+		t.Errorf("expected the original entry, got %v %v", e.Timestamp, e.Fields)
+	}
+
+	buf.Logger().Info("late")
+	buf.Logger().Debug("late debug") // Below the parent's level
+	if len(rec.entries) != 2 || !buf.End(nil) {
+		t.Errorf("expected entries after End to be written directly at the parent's level, got %d", len(rec.entries))
+	}
+
+	rec.entries = nil
+	_, buf = logger.Buffer(context.Background())
+	buf.Logger().Info("charging")
+	buf.Logger().Error("card rejected")
+	if !buf.Failed() || !buf.End(nil) || len(rec.entries) != 2 {
+		t.Errorf("expected an Error entry to flush the buffer, got %d entries", len(rec.entries))
+	}
+}
+
+// TestRequestBuffer_Slow verifies the slow threshold and the capacity bound.
+func TestRequestBuffer_Slow(t *testing.T) {
+	rec := &entryRecorder{}
+	logger := ll.New("app").Enable().Handler(rec)
+
+	_, buf := logger.Buffer(context.Background(), ll.WithBufferSlow(time.Millisecond), ll.WithBufferCapacity(2))
+	for _, msg := range []string{"a", "b", "c"} {
+		buf.Logger().Info(msg)
+	}
+	time.Sleep(2 * time.Millisecond)
+	if !buf.End(nil) {
+		t.Fatal("expected a slow unit of work to be flushed")
+	}
+	if buf.Dropped() != 1 || len(rec.entries) != 2 || rec.entries[0].Message != "b" {
+		t.Errorf("expected the 2 most recent entries, got %d (dropped %d)", len(rec.entries), buf.Dropped())
+	}
+}
+
+// TestRequestBuffer_Trace verifies that buffered entries carry the span of the context.
+func TestRequestBuffer_Trace(t *testing.T) {
+	rec := &entryRecorder{}
+	logger := ll.New("app").Enable().Handler(rec)
+
+	ctx, span := logger.Span(context.Background(), "checkout")
+	ctx, buf := logger.Buffer(ctx)
+	ll.FromContext(ctx).Info("charging")
+	buf.Fail()
+	buf.End(nil)
+	span.End()
+
+	if len(rec.entries) != 2 || fieldString(rec.entries[0], ll.TraceIDKey) != span.Context().TraceID {
+		t.Errorf("expected the buffered entry to carry the trace ID, got %v", rec.entries)
+	}
+}
+
+// TestRequestBuffer_Recorder verifies that a parent's recorder does not take entries the
+// buffer captures, and that WithBufferLevel limits what is captured.
+func TestRequestBuffer_Recorder(t *testing.T) {
+	rec := &entryRecorder{}
+	flight := lh.NewFlightRecorder()
+	logger := ll.New("app").Enable().Level(lx.LevelInfo).Handler(lh.Pipe(rec, flight.Wrap)).Recorder(flight)
+
+	_, buf := logger.Buffer(context.Background())
+	buf.Logger().Debug("loading cart")
+	if buf.Len() != 1 {
+		t.Fatalf("expected the Debug entry in the buffer, got %d entries", buf.Len())
+	}
+	buf.End(errors.New("failed"))
+	if len(rec.entries) != 1 || rec.entries[0].Message != "loading cart" {
+		t.Errorf("expected the buffered entry to be flushed, got %v", rec.entries)
+	}
+	if v, _ := rec.entries[0].Fields.Get(lh.BackfillKey); v == true {
+		t.Error("expected the entry to come from the buffer, not the flight recorder")
+	}
+
+	_, buf = logger.Buffer(context.Background(), ll.WithBufferLevel(lx.LevelWarn))
+	buf.Logger().Info("ignored")
+	if buf.Len() != 0 {
+		t.Errorf("expected entries below the capture level to be dropped, got %d", buf.Len())
+	}
+}
@@ -0,0 +1,76 @@
+package tests
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/olekukonko/ll/lm"
+	"github.com/olekukonko/ll/lx"
+)
+
+// TestKeyedSampling verifies that entries sharing a key are kept or dropped together.
+func TestKeyedSampling(t *testing.T) {
+	sampler := lm.NewKeyedSampling(0.5, "trace_id", "request_id")
+
+	keptTraces := 0
+	for i := 0; i < 1000; i++ {
+		id := fmt.Sprintf("trace-%d", i)
+		first := sampler.Handle(&lx.Entry{Fields: lx.Fields{lx.String("trace_id", id)}}) == nil
+		for j := 0; j < 4; j++ {
+			// Same trace via a boxed value must get the same decision.
+			kept := sampler.Handle(&lx.Entry{Fields: lx.Fields{{Key: "trace_id", Value: id}}}) == nil
+			if kept != first {
+				t.Fatalf("trace %s: inconsistent decision", id)
+			}
+		}
+		if first {
+			keptTraces++
+		}
+	}
+	if keptTraces < 400 || keptTraces > 600 {
+		t.Errorf("expected roughly half of the traces kept, got %d/1000", keptTraces)
+	}
+
+	// Falls back to the next key, and keeps entries without any key.
+	if err := sampler.Handle(&lx.Entry{}); err != nil {
+		t.Errorf("expected entry without key to be kept, got %v", err)
+	}
+	stats := sampler.GetStats()
+	if stats.Kept+stats.Dropped != 5001 || stats.Kept != uint64(keptTraces*5+1) {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+
+	all, none := lm.NewKeyedSampling(1, "id"), lm.NewKeyedSampling(0, "id")
+	e := &lx.Entry{Fields: lx.Fields{lx.Int("id", 7)}}
+	if all.Handle(e) != nil || none.Handle(e) == nil {
+		t.Error("expected rate 1 to keep and rate 0 to drop")
+	}
+}
+
+// TestBurstSampling verifies first-N-then-every-Mth sampling per message and interval.
+func TestBurstSampling(t *testing.T) {
+	sampler := lm.NewBurstSampling(3, 5, 50*time.Millisecond)
+
+	kept := 0
+	for i := 0; i < 23; i++ {
+		if sampler.Handle(&lx.Entry{Level: lx.LevelInfo, Message: "hot"}) == nil {
+			kept++
+		}
+	}
+	// Entries 1-3 are kept, then 8, 13, 18, 23.
+	if kept != 7 {
+		t.Errorf("expected 7 kept entries, got %d", kept)
+	}
+	if sampler.Handle(&lx.Entry{Level: lx.LevelInfo, Message: "cold"}) != nil {
+		t.Error("expected a different message to have its own budget")
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	if sampler.Handle(&lx.Entry{Level: lx.LevelInfo, Message: "hot"}) != nil {
+		t.Error("expected the budget to reset after the interval")
+	}
+	if stats := sampler.GetStats(); stats.Kept != 9 || stats.Dropped != 16 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+}
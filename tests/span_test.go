@@ -0,0 +1,104 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/olekukonko/ll"
+	"github.com/olekukonko/ll/lx"
+)
+
+// fieldString returns a string field of a recorded entry.
+func fieldString(e lx.Entry, key string) string {
+	v, _ := e.Fields.Get(key)
+	s, _ := v.(string)
+	return s
+}
+
+// TestSpan_Nesting verifies IDs, parent links and context propagation.
+func TestSpan_Nesting(t *testing.T) {
+	rec := &entryRecorder{}
+	logger := ll.New("app").Enable().Handler(rec)
+
+	ctx, root := logger.Span(context.Background(), "request")
+	root.Fields("route", "/checkout")
+	childCtx, child := logger.Span(ctx, "db.query")
+	logger.Ctx(childCtx).Info("querying")
+	logger.Info("no span")
+	time.Sleep(time.Millisecond)
+	child.Fail(errors.New("timeout")).End()
+	root.End()
+	root.End() // Only the first call logs
+
+	if len(rec.entries) != 4 {
+		t.Fatalf("expected 4 entries, got %d", len(rec.entries))
+	}
+	inner, plain, childEnd, rootEnd := rec.entries[0], rec.entries[1], rec.entries[2], rec.entries[3]
+
+	rc, cc := root.Context(), child.Context()
+	if len(rc.TraceID) != 32 || len(rc.SpanID) != 16 || cc.TraceID != rc.TraceID || cc.SpanID == rc.SpanID {
+		t.Fatalf("unexpected span contexts: root %+v, child %+v", rc, cc)
+	}
+	if child.ParentID() != rc.SpanID || root.ParentID() != "" {
+		t.Errorf("unexpected parent IDs: %q, %q", child.ParentID(), root.ParentID())
+	}
+	if fieldString(inner, ll.TraceIDKey) != rc.TraceID || fieldString(inner, ll.SpanIDKey) != cc.SpanID {
+		t.Errorf("expected entry logged with child context to carry its IDs, got %v", inner.Fields)
+	}
+	if _, ok := plain.Fields.Get(ll.TraceIDKey); ok {
+		t.Errorf("expected no IDs without a span, got %v", plain.Fields)
+	}
+
+	if childEnd.Message != "db.query" || childEnd.Level != lx.LevelError || childEnd.Class != lx.ClassText || childEnd.Error == nil {
+		t.Errorf("expected failed child span end as an Error text entry, got %+v", childEnd)
+	}
+	if fieldString(childEnd, "status") != "error" || fieldString(childEnd, ll.ParentIDKey) != rc.SpanID {
+		t.Errorf("unexpected child end fields: %v", childEnd.Fields)
+	}
+	if d, _ := childEnd.Fields.Get("duration"); d.(time.Duration) < time.Millisecond {
+		t.Errorf("expected duration of at least 1ms, got %v", d)
+	}
+
+	if rootEnd.Level != lx.LevelInfo || rootEnd.Class != lx.ClassTimed || fieldString(rootEnd, "status") != "ok" || fieldString(rootEnd, "route") != "/checkout" {
+		t.Errorf("unexpected root end: %+v", rootEnd)
+	}
+	if _, ok := rootEnd.Fields.Get(ll.ParentIDKey); ok {
+		t.Errorf("expected no parent on root span, got %v", rootEnd.Fields)
+	}
+}
+
+// TestSpan_Starts verifies start entries with WithSpanStarts.
+func TestSpan_Starts(t *testing.T) {
+	rec := &entryRecorder{}
+	logger := ll.New("app", ll.WithSpanStarts(true)).Enable().Handler(rec)
+
+	_, span := logger.Span(context.Background(), "job")
+	span.End()
+
+	if len(rec.entries) != 2 {
+		t.Fatalf("expected start and end entries, got %d", len(rec.entries))
+	}
+	if fieldString(rec.entries[0], "span") != "start" || fieldString(rec.entries[1], "span") != "end" {
+		t.Errorf("unexpected span events: %v, %v", rec.entries[0].Fields, rec.entries[1].Fields)
+	}
+	if fieldString(rec.entries[0], ll.SpanIDKey) != span.Context().SpanID {
+		t.Errorf("expected start entry to carry the span ID, got %v", rec.entries[0].Fields)
+	}
+}
+
+// TestSpan_Suspended verifies that spans of a suspended logger log nothing.
+func TestSpan_Suspended(t *testing.T) {
+	rec := &entryRecorder{}
+	logger := ll.New("app", ll.WithSpanStarts(true)).Enable().Handler(rec)
+
+	_, running := logger.Span(context.Background(), "running")
+	logger.Suspend()
+	_, span := logger.Span(context.Background(), "job")
+	span.End()
+	running.End()
+	if len(rec.entries) != 1 || fieldString(rec.entries[0], "span") != "start" {
+		t.Errorf("expected only the start entry logged before Suspend, got %d entries", len(rec.entries))
+	}
+}
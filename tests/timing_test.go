@@ -0,0 +1,112 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/olekukonko/ll"
+	"github.com/olekukonko/ll/lh"
+	"github.com/olekukonko/ll/lx"
+)
+
+// TestTimings_Percentiles verifies the statistics of a streaming histogram.
+func TestTimings_Percentiles(t *testing.T) {
+	timings := ll.NewTimings(ll.New("app"), 0)
+	for i := 1; i <= 1000; i++ {
+		timings.Record("app", "op", time.Duration(i)*time.Microsecond)
+	}
+
+	stats := timings.Snapshot()
+	if len(stats) != 1 {
+		t.Fatalf("expected 1 series, got %d", len(stats))
+	}
+	st := stats[0]
+	if st.Count != 1000 || st.Min != time.Microsecond || st.Max != time.Millisecond {
+		t.Errorf("unexpected count/min/max: %+v", st)
+	}
+	if st.Mean != 500500*time.Nanosecond {
+		t.Errorf("expected mean 500.5µs, got %v", st.Mean)
+	}
+	within := func(name string, got, want time.Duration) {
+		if diff := float64(got-want) / float64(want); diff > 0.04 || diff < -0.04 {
+			t.Errorf("%s: expected ~%v, got %v", name, want, got)
+		}
+	}
+	within("p50", st.P50, 500*time.Microsecond)
+	within("p95", st.P95, 950*time.Microsecond)
+	within("p99", st.P99, 990*time.Microsecond)
+}
+
+// entryRecorder keeps a copy of every entry it handles.
+type entryRecorder struct {
+	entries []lx.Entry
+}
+
+func (r *entryRecorder) Handle(e *lx.Entry) error {
+	c := *e
+	c.Fields = append(lx.Fields(nil), e.Fields...)
+	r.entries = append(r.entries, c)
+	return nil
+}
+
+// TestTimings_Logger verifies that Since and Measure feed an attached aggregator
+// instead of logging each call, and that Flush logs one summary per series.
+func TestTimings_Logger(t *testing.T) {
+	rec := &entryRecorder{}
+	logger := ll.New("app").Enable().Handler(rec)
+	timings := ll.NewTimings(logger, 0)
+	logger.Timings(timings)
+
+	db := logger.Namespace("db")
+	for i := 0; i < 100; i++ {
+		db.Since().Info("query")
+	}
+	logger.Labels("load")
+	logger.Measure(func() {}, func() {})
+	logger.Since().Record("manual")
+	logger.Since().If(false).Record("skipped")
+
+	if got := len(rec.entries); got != 0 {
+		t.Fatalf("expected no per-call entries, got %d", got)
+	}
+
+	timings.Close()
+	entries := rec.entries
+	var labels []string
+	for _, e := range entries {
+		if e.Class != lx.ClassTimed {
+			t.Errorf("expected timed class, got %v", e.Class)
+		}
+		ns, _ := e.Fields.Get("namespace")
+		labels = append(labels, ns.(string)+":"+e.Message)
+	}
+	if got := strings.Join(labels, ","); got != "app:fn_1,app:load,app:manual,app/db:query" {
+		t.Errorf("unexpected summaries: %s", got)
+	}
+	if count, _ := entries[3].Fields.Get("count"); count != int64(100) {
+		t.Errorf("expected count 100, got %v", count)
+	}
+	if len(timings.Snapshot()) != 0 {
+		t.Error("expected flush to start a new window")
+	}
+
+	timings.Record("app", "suspended", time.Millisecond)
+	logger.Suspend()
+	timings.Flush()
+	if len(rec.entries) != len(entries) || len(timings.Snapshot()) != 0 {
+		t.Errorf("expected a suspended logger to discard the window, got %d entries", len(rec.entries))
+	}
+}
+
+// TestTimings_Entries verifies that WithTimingEntries keeps per-call entries.
+func TestTimings_Entries(t *testing.T) {
+	mem := lh.NewMemoryHandler()
+	logger := ll.New("app").Enable().Handler(mem)
+	logger.Timings(ll.NewTimings(logger, 0, ll.WithTimingEntries()))
+
+	logger.Since().Info("step")
+	if got := len(mem.Entries()); got != 1 {
+		t.Errorf("expected the per-call entry, got %d", got)
+	}
+}
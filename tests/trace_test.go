@@ -0,0 +1,110 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/olekukonko/ll"
+)
+
+// TestParseTraceparent verifies parsing and formatting of W3C traceparent values.
+func TestParseTraceparent(t *testing.T) {
+	const valid = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	sc, ok := ll.ParseTraceparent(valid)
+	if !ok || sc.TraceID != "4bf92f3577b34da6a3ce929d0e0e4736" || sc.SpanID != "00f067aa0ba902b7" || sc.Flags != 1 {
+		t.Fatalf("unexpected parse result: %+v, %v", sc, ok)
+	}
+	if got := sc.Traceparent(); got != valid {
+		t.Errorf("expected round trip %q, got %q", valid, got)
+	}
+	if _, ok := ll.ParseTraceparent("01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-future"); !ok {
+		t.Error("expected later versions with extra parts to parse")
+	}
+
+	for _, bad := range []string{
+		"",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra",
+		"00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01",
+		"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"00_4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+	} {
+		if _, ok := ll.ParseTraceparent(bad); ok {
+			t.Errorf("expected %q to be rejected", bad)
+		}
+	}
+}
+
+// TestTracePropagation verifies that a trace flows from an incoming request, through the
+// request-scoped logger, to an outgoing request.
+func TestTracePropagation(t *testing.T) {
+	var downstream http.Header
+	inventory := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		downstream = r.Header.Clone()
+	}))
+	defer inventory.Close()
+
+	rec := &entryRecorder{}
+	logger := ll.New("api").Enable().Handler(rec)
+	client := &http.Client{Transport: ll.TraceTransport(nil)}
+
+	var serverSpan ll.SpanContext
+	api := httptest.NewServer(ll.TraceMiddleware(logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		serverSpan, _ = ll.SpanContextFrom(r.Context())
+		ll.FromContext(r.Context()).Info("handling")
+		req, _ := http.NewRequestWithContext(r.Context(), "GET", inventory.URL, nil)
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Errorf("downstream call failed: %v", err)
+			return
+		}
+		resp.Body.Close()
+	})))
+	defer api.Close()
+
+	req, _ := http.NewRequest("GET", api.URL, nil)
+	req.Header.Set(ll.TraceparentHeader, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	req.Header.Set(ll.TracestateHeader, "vendor=abc")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	resp.Body.Close()
+
+	if serverSpan.TraceID != "4bf92f3577b34da6a3ce929d0e0e4736" || serverSpan.SpanID == "00f067aa0ba902b7" {
+		t.Fatalf("expected the trace continued with a new span, got %+v", serverSpan)
+	}
+	if len(rec.entries) != 1 {
+		t.Fatalf("expected 1 entry, got %d", len(rec.entries))
+	}
+	e := rec.entries[0]
+	if fieldString(e, ll.TraceIDKey) != serverSpan.TraceID || fieldString(e, ll.SpanIDKey) != serverSpan.SpanID ||
+		fieldString(e, ll.ParentIDKey) != "00f067aa0ba902b7" {
+		t.Errorf("unexpected request logger fields: %v", e.Fields)
+	}
+	if got, want := downstream.Get(ll.TraceparentHeader), serverSpan.Traceparent(); got != want {
+		t.Errorf("expected downstream traceparent %q, got %q", want, got)
+	}
+	if got := downstream.Get(ll.TracestateHeader); got != "vendor=abc" {
+		t.Errorf("expected tracestate to be propagated, got %q", got)
+	}
+}
+
+// TestTraceMiddleware_NewTrace verifies that requests without a traceparent start a trace.
+func TestTraceMiddleware_NewTrace(t *testing.T) {
+	var sc ll.SpanContext
+	h := ll.TraceMiddleware(ll.New("api"))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sc, _ = ll.SpanContextFrom(r.Context())
+		if ll.FromContext(r.Context()) == ll.FromContext(context.Background()) {
+			t.Error("expected a request-scoped logger")
+		}
+	}))
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil))
+
+	if _, ok := ll.ParseTraceparent(sc.Traceparent()); !ok {
+		t.Errorf("expected a valid new trace, got %+v", sc)
+	}
+}
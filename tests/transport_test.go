@@ -0,0 +1,210 @@
+package tests
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/olekukonko/ll"
+	"github.com/olekukonko/ll/lx"
+)
+
+// TestLogTransport verifies the summary entry and body capture of outgoing requests.
+func TestLogTransport(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=secret")
+		w.Write([]byte(`{"echo":` + string(body) + `}`))
+	}))
+	defer srv.Close()
+
+	rec := &entryRecorder{}
+	logger := ll.New("client").Enable().Handler(rec)
+	client := &http.Client{Transport: ll.LogTransport(logger, nil,
+		ll.WithTransportBodies(1024),
+		ll.WithTransportHeaders("X-Api-Key"),
+	)}
+
+	req, _ := http.NewRequest("POST", srv.URL+"/users", strings.NewReader(`{"name":"alice"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Api-Key", "k-123")
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if string(body) != `{"echo":{"name":"alice"}}` {
+		t.Errorf("response body altered by capture: %s", body)
+	}
+
+	if len(rec.entries) != 3 {
+		t.Fatalf("expected summary and two body entries, got %d", len(rec.entries))
+	}
+	e := rec.entries[0]
+	if e.Message != "POST "+srv.URL+"/users" || e.Level != lx.LevelInfo {
+		t.Errorf("unexpected summary: %q at %v", e.Message, e.Level)
+	}
+	if v, _ := e.Fields.Get("status"); v != int64(200) {
+		t.Errorf("expected status 200, got %v", v)
+	}
+	reqHeaders, _ := e.Fields.Get("request_headers")
+	if h, _ := reqHeaders.(map[string]string); h["X-Api-Key"] != "[REDACTED]" || h["Content-Type"] != "application/json" {
+		t.Errorf("unexpected request headers: %v", reqHeaders)
+	}
+	respHeaders, _ := e.Fields.Get("response_headers")
+	if h, _ := respHeaders.(map[string]string); h["Set-Cookie"] != "[REDACTED]" {
+		t.Errorf("expected Set-Cookie redacted, got %v", respHeaders)
+	}
+
+	want := "[POST " + srv.URL + "/users] request body:\n{\n    \"name\": \"alice\"\n  }"
+	if rec.entries[1].Class != lx.ClassOutput || rec.entries[1].Message != want {
+		t.Errorf("unexpected request body entry:\n%s", rec.entries[1].Message)
+	}
+	if !strings.Contains(rec.entries[2].Message, "response body:\n{\n    \"echo\": {") {
+		t.Errorf("unexpected response body entry:\n%s", rec.entries[2].Message)
+	}
+}
+
+// TestLogTransport_Filters verifies body size caps and content-type filters.
+func TestLogTransport_Filters(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/image" {
+			w.Header().Set("Content-Type", "image/png")
+			w.Write([]byte("\x89PNG"))
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.Write([]byte(strings.Repeat("x", 100)))
+	}))
+	defer srv.Close()
+
+	rec := &entryRecorder{}
+	logger := ll.New("client").Enable().Handler(rec)
+	client := &http.Client{Transport: ll.LogTransport(logger, nil, ll.WithTransportBodies(10))}
+
+	for _, path := range []string{"/image", "/text"} {
+		resp, err := client.Get(srv.URL + path)
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if path == "/text" && len(body) != 100 {
+			t.Errorf("expected full body despite truncated capture, got %d bytes", len(body))
+		}
+	}
+
+	if len(rec.entries) != 3 {
+		t.Fatalf("expected 2 summaries and 1 body entry, got %d", len(rec.entries))
+	}
+	if msg := rec.entries[2].Message; !strings.HasSuffix(msg, ":\nxxxxxxxxxx\n  ... (truncated)") {
+		t.Errorf("unexpected truncated body entry:\n%s", msg)
+	}
+}
+
+// TestLogTransport_Retries verifies retries of idempotent requests and the error level.
+func TestLogTransport_Retries(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	rec := &entryRecorder{}
+	logger := ll.New("client").Enable().Handler(rec)
+	client := &http.Client{Transport: ll.LogTransport(logger, nil, ll.WithTransportRetries(3, time.Millisecond))}
+
+	resp, err := client.Get(srv.URL)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound || calls.Load() != 3 {
+		t.Errorf("expected 404 after 3 calls, got %d after %d", resp.StatusCode, calls.Load())
+	}
+	if len(rec.entries) != 1 {
+		t.Fatalf("expected 1 entry, got %d", len(rec.entries))
+	}
+	if v, _ := rec.entries[0].Fields.Get("retries"); v != int64(2) || rec.entries[0].Level != lx.LevelWarn {
+		t.Errorf("expected 2 retries at Warn, got %v at %v", v, rec.entries[0].Level)
+	}
+
+	// Non-idempotent requests are sent once.
+	calls.Store(0)
+	resp, err = client.Post(srv.URL, "text/plain", strings.NewReader("x"))
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	resp.Body.Close()
+	if calls.Load() != 1 || rec.entries[1].Level != lx.LevelError {
+		t.Errorf("expected a single POST logged at Error, got %d calls at %v", calls.Load(), rec.entries[1].Level)
+	}
+
+	// Transport errors are logged at Error with the error attached.
+	srv.Close()
+	if _, err := client.Get(srv.URL); err == nil {
+		t.Fatal("expected an error from a closed server")
+	}
+	last := rec.entries[len(rec.entries)-1]
+	if last.Level != lx.LevelError || last.Error == nil {
+		t.Errorf("expected transport error at Error, got %v (err %v)", last.Level, last.Error)
+	}
+}
+
+// TestLogTransport_Streaming verifies that response capture does not read ahead of the
+// caller, and that a body closed early is logged as truncated.
+func TestLogTransport_Streaming(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write([]byte("event 1\n"))
+		w.(http.Flusher).Flush()
+		<-release // The stream stays open until the test is done
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	rec := &entryRecorder{}
+	logger := ll.New("client").Enable().Handler(rec)
+	client := &http.Client{Transport: ll.LogTransport(logger, nil, ll.WithTransportBodies(1024))}
+
+	done := make(chan *http.Response, 1)
+	go func() {
+		resp, err := client.Get(srv.URL + "/events")
+		if err != nil {
+			t.Errorf("request failed: %v", err)
+		}
+		done <- resp
+	}()
+	var resp *http.Response
+	select {
+	case resp = <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected the response before the stream ends")
+	}
+	if resp == nil {
+		return
+	}
+
+	chunk := make([]byte, 8)
+	if _, err := io.ReadFull(resp.Body, chunk); err != nil || string(chunk) != "event 1\n" {
+		t.Fatalf("expected the first event, got %q (%v)", chunk, err)
+	}
+	if len(rec.entries) != 1 {
+		t.Fatalf("expected only the summary before the body is closed, got %d entries", len(rec.entries))
+	}
+	resp.Body.Close()
+	if len(rec.entries) != 2 || !strings.Contains(rec.entries[1].Message, "event 1\n\n  ... (truncated)") {
+		t.Errorf("expected the captured part logged as truncated, got %v", rec.entries)
+	}
+}
@@ -0,0 +1,172 @@
+package tests
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/olekukonko/ll"
+	"github.com/olekukonko/ll/lh"
+	"github.com/olekukonko/ll/lm"
+	"github.com/olekukonko/ll/lx"
+)
+
+// TestMiddleWrap verifies that lx.Wrap stages work as logger middleware.
+func TestMiddleWrap(t *testing.T) {
+	t.Run("Dedup", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		logger := ll.New("test").Enable().Handler(lh.NewTextHandler(buf))
+		logger.Use(ll.MiddleWrap(lh.PipeDedup(time.Minute)))
+
+		logger.Info("same")
+		logger.Info("same")
+		logger.Info("different")
+
+		if got := strings.Count(buf.String(), "INFO: same"); got != 1 {
+			t.Errorf("expected 1 deduplicated line, got %d in %q", got, buf.String())
+		}
+		if !strings.Contains(buf.String(), "INFO: different") {
+			t.Errorf("expected distinct entry to pass, got %q", buf.String())
+		}
+	})
+
+	t.Run("Filter", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		logger := ll.New("test").Enable().Handler(lh.NewTextHandler(buf))
+		logger.Use(ll.MiddleWrap(lh.PipeFilter(func(e *lx.Entry) bool {
+			return e.Level == lx.LevelWarn
+		})))
+
+		logger.Info("dropped")
+		logger.Warn("kept")
+
+		if strings.Contains(buf.String(), "dropped") || !strings.Contains(buf.String(), "kept") {
+			t.Errorf("unexpected output %q", buf.String())
+		}
+	})
+
+	t.Run("Map", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		logger := ll.New("test").Enable().Handler(lh.NewTextHandler(buf))
+		logger.Use(ll.MiddleWrap(lh.PipeMap(func(e *lx.Entry) {
+			e.Message = strings.ToUpper(e.Message)
+			e.Fields = append(e.Fields, lx.Field{Key: "mapped", Value: true})
+		})))
+
+		logger.Fields("key", "value").Info("hello")
+
+		if !strings.Contains(buf.String(), "INFO: HELLO [key=value mapped=true]") {
+			t.Errorf("expected mapped output, got %q", buf.String())
+		}
+	})
+
+	t.Run("Id", func(t *testing.T) {
+		var seen []int
+		logger := ll.New("test").Enable().Handler(lh.NewMemoryHandler())
+		logger.Use(ll.Middle(func(e *lx.Entry) error {
+			e.Id = 42
+			return nil
+		}))
+		logger.Use(ll.MiddleWrap(lh.PipeMap(func(e *lx.Entry) {
+			seen = append(seen, e.Id)
+			e.Id = 7 // Changes made by the wrap are applied to the logged entry
+		})))
+
+		logger.Info("hello")
+
+		if len(seen) != 1 || seen[0] != 42 {
+			t.Errorf("expected the wrap to see Id 42, got %v", seen)
+		}
+		if entries := logger.GetHandler().(*lh.MemoryHandler).Entries(); len(entries) != 1 || entries[0].Id != 7 {
+			t.Errorf("expected the mapped Id to be logged, got %+v", entries)
+		}
+	})
+
+	t.Run("Extra", func(t *testing.T) {
+		rec := &entryRecorder{}
+		logger := ll.New("test").Enable().Handler(rec)
+		logger.Use(ll.MiddleWrap(lh.PipeKeyedRate(lm.NewKeyedRateLimiter(lm.RateByMessage, 1, 20*time.Millisecond))))
+
+		logger.Info("busy")
+		logger.Info("busy") // Suppressed
+		time.Sleep(30 * time.Millisecond)
+		logger.Info("busy") // Preceded by a summary, which middleware rejects
+
+		if len(rec.entries) != 2 || rec.entries[1].Message != "busy" {
+			t.Errorf("expected only the call's entries to be logged, got %d", len(rec.entries))
+		}
+	})
+
+	t.Run("Async", func(t *testing.T) {
+		var errs []error
+		var mu sync.Mutex
+		var wg sync.WaitGroup
+		release := make(chan struct{})
+		logger := ll.New("test").Enable().Handler(lh.NewMemoryHandler())
+		logger.Use(ll.MiddleWrap(func(next lx.Handler) lx.Handler {
+			return lx.HandlerFunc(func(e *lx.Entry) error {
+				wg.Add(1)
+				go func(c lx.Entry) { // Forwarded from another goroutine, after the call
+					defer wg.Done()
+					<-release
+					err := next.Handle(&c)
+					mu.Lock()
+					errs = append(errs, err)
+					mu.Unlock()
+				}(*e)
+				return next.Handle(e)
+			})
+		}))
+
+		for i := 0; i < 50; i++ {
+			logger.Info("hello")
+		}
+		close(release)
+		wg.Wait()
+
+		if n := logger.GetHandler().(*lh.MemoryHandler).Len(); n != 50 {
+			t.Errorf("expected each call's entry to be logged once, got %d", n)
+		}
+		for _, err := range errs {
+			if err == nil {
+				t.Fatal("expected entries forwarded outside of a call to be rejected")
+			}
+		}
+	})
+
+	t.Run("DedupSummary", func(t *testing.T) {
+		ttl := 20 * time.Millisecond
+		repeats := func(logger *ll.Logger, mem *lh.MemoryHandler) int {
+			for i := 0; i < 20; i++ {
+				logger.Info("retrying") // Logged while the cleanup goroutine emits
+				time.Sleep(ttl / 4)
+			}
+			time.Sleep(3 * ttl)
+			n := 0
+			for _, e := range mem.Entries() {
+				if _, ok := e.Fields.Get("repeat_count"); ok {
+					n++
+				}
+			}
+			return n
+		}
+
+		mem := lh.NewMemoryHandler()
+		dedup := lh.Pipe(mem, lh.PipeDedup(ttl, lh.WithDedupSummary()))
+		defer dedup.(*lh.Dedup).Close()
+		if n := repeats(ll.New("test").Enable().Handler(dedup), mem); n == 0 {
+			t.Error("expected summaries from a Dedup used as a handler")
+		}
+
+		mem = lh.NewMemoryHandler()
+		logger := ll.New("test").Enable().Handler(mem)
+		wrap := ll.MiddleWrap(lh.PipeDedup(ttl, lh.WithDedupSummary()))
+		defer wrap.(interface{ Close() error }).Close()
+		logger.Use(wrap)
+		if n := repeats(logger, mem); n != 0 {
+			t.Errorf("expected summaries to be rejected through MiddleWrap, got %d", n)
+		}
+	})
+}
@@ -0,0 +1,282 @@
+package ll
+
+import (
+	"math"
+	"math/bits"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/olekukonko/ll/lx"
+)
+
+// histogramBuckets is the number of buckets in a timing histogram. Durations below 32ns
+// are counted exactly; above that, each power of two is split into 16 buckets, bounding
+// the relative error of reported percentiles to about 3%.
+const histogramBuckets = 60*16 + 16
+
+// Timings aggregates durations recorded by SinceBuilder and Logger.Measure into streaming
+// histograms, one per namespace and label, and periodically logs a summary of each:
+// count, min, max, mean, p50, p95 and p99. Memory use per series is fixed regardless
+// of how many durations are recorded. Thread-safe.
+//
+// Attach it to a logger with Logger.Timings so timed operations feed it instead of
+// logging one entry per call, or call Record directly.
+type Timings struct {
+	logger   *Logger
+	interval time.Duration
+	entries  bool
+
+	mu     sync.RWMutex
+	series map[timingKey]*timingSeries
+
+	done chan struct{}
+	wg   sync.WaitGroup
+	once sync.Once
+}
+
+// TimingsOption configures a Timings aggregator.
+type TimingsOption func(*Timings)
+
+// WithTimingEntries keeps logging one entry per timed operation in addition to
+// aggregating it. By default, loggers with Timings attached only aggregate.
+func WithTimingEntries() TimingsOption {
+	return func(t *Timings) {
+		t.entries = true
+	}
+}
+
+// TimingStats summarizes the durations recorded for a namespace and label.
+type TimingStats struct {
+	Namespace string
+	Label     string
+	Count     int64
+	Min       time.Duration
+	Max       time.Duration
+	Mean      time.Duration
+	P50       time.Duration
+	P95       time.Duration
+	P99       time.Duration
+}
+
+// timingKey identifies a series.
+type timingKey struct {
+	namespace string
+	label     string
+}
+
+// timingSeries is a streaming histogram of durations.
+type timingSeries struct {
+	mu      sync.Mutex
+	count   int64
+	sum     float64
+	min     int64
+	max     int64
+	buckets [histogramBuckets]uint64
+}
+
+// NewTimings creates an aggregator that logs a summary per namespace and label to
+// logger every interval, then starts a new window. With an interval of 0, summaries
+// are only logged by Flush and Close.
+// Example:
+//
+//	timings := ll.NewTimings(logger, time.Minute)
+//	defer timings.Close()
+//	logger.Timings(timings)
+//	for _, item := range items {
+//	    sb := logger.Since()
+//	    process(item)
+//	    sb.Info("process") // Aggregated under "process", no entry per call
+//	}
+func NewTimings(logger *Logger, interval time.Duration, opts ...TimingsOption) *Timings {
+	t := &Timings{
+		logger:   logger,
+		interval: interval,
+		series:   make(map[timingKey]*timingSeries),
+		done:     make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+	if interval > 0 {
+		t.wg.Add(1)
+		go t.flushLoop()
+	}
+	return t
+}
+
+// Record adds a duration to the series for namespace and label.
+func (t *Timings) Record(namespace, label string, d time.Duration) {
+	key := timingKey{namespace: namespace, label: label}
+	t.mu.RLock()
+	s, ok := t.series[key]
+	t.mu.RUnlock()
+	if !ok {
+		t.mu.Lock()
+		if s, ok = t.series[key]; !ok {
+			s = &timingSeries{}
+			t.series[key] = s
+		}
+		t.mu.Unlock()
+	}
+	s.record(d)
+}
+
+// Snapshot returns the statistics of the current window, sorted by namespace and label.
+func (t *Timings) Snapshot() []TimingStats {
+	return t.collect(false)
+}
+
+// Flush logs the summary of every series with recorded durations and starts a new window.
+// While the logger is suspended, the window is discarded without logging.
+func (t *Timings) Flush() {
+	stats := t.collect(true)
+	if t.logger.suspend.Load() {
+		return
+	}
+	for _, st := range stats {
+		t.logger.log(lx.LevelInfo, lx.ClassTimed, st.Label, lx.Fields{
+			lx.String("namespace", st.Namespace),
+			lx.Int64("count", st.Count),
+			lx.Duration("min", st.Min),
+			lx.Duration("max", st.Max),
+			lx.Duration("mean", st.Mean),
+			lx.Duration("p50", st.P50),
+			lx.Duration("p95", st.P95),
+			lx.Duration("p99", st.P99),
+		}, false)
+	}
+}
+
+// Close stops periodic flushing and logs the final summaries.
+func (t *Timings) Close() {
+	t.once.Do(func() {
+		close(t.done)
+		t.wg.Wait()
+		t.Flush()
+	})
+}
+
+// flushLoop logs summaries every interval until Close is called.
+func (t *Timings) flushLoop() {
+	defer t.wg.Done()
+	ticker := time.NewTicker(t.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			t.Flush()
+		case <-t.done:
+			return
+		}
+	}
+}
+
+// collect returns the statistics of every series with recorded durations, resetting
+// them if reset is true.
+func (t *Timings) collect(reset bool) []TimingStats {
+	t.mu.RLock()
+	stats := make([]TimingStats, 0, len(t.series))
+	for key, s := range t.series {
+		if st, ok := s.stats(reset); ok {
+			st.Namespace, st.Label = key.namespace, key.label
+			stats = append(stats, st)
+		}
+	}
+	t.mu.RUnlock()
+
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Namespace != stats[j].Namespace {
+			return stats[i].Namespace < stats[j].Namespace
+		}
+		return stats[i].Label < stats[j].Label
+	})
+	return stats
+}
+
+// record adds a duration to the series.
+func (s *timingSeries) record(d time.Duration) {
+	v := int64(d)
+	if v < 0 {
+		v = 0
+	}
+	s.mu.Lock()
+	if s.count == 0 || v < s.min {
+		s.min = v
+	}
+	if v > s.max {
+		s.max = v
+	}
+	s.count++
+	s.sum += float64(v)
+	s.buckets[bucketIndex(uint64(v))]++
+	s.mu.Unlock()
+}
+
+// stats computes the statistics of the series, reporting false if it is empty.
+func (s *timingSeries) stats(reset bool) (TimingStats, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.count == 0 {
+		return TimingStats{}, false
+	}
+	st := TimingStats{
+		Count: s.count,
+		Min:   time.Duration(s.min),
+		Max:   time.Duration(s.max),
+		Mean:  time.Duration(math.Round(s.sum / float64(s.count))),
+		P50:   s.quantile(0.50),
+		P95:   s.quantile(0.95),
+		P99:   s.quantile(0.99),
+	}
+	if reset {
+		s.count, s.sum, s.min, s.max = 0, 0, 0, 0
+		s.buckets = [histogramBuckets]uint64{}
+	}
+	return st, true
+}
+
+// quantile returns the estimated q-quantile, clamped to the observed range.
+// Caller must hold the lock.
+func (s *timingSeries) quantile(q float64) time.Duration {
+	rank := uint64(math.Ceil(q * float64(s.count)))
+	if rank == 0 {
+		rank = 1
+	}
+	var seen uint64
+	for i, n := range s.buckets {
+		seen += n
+		if seen >= rank {
+			v := bucketValue(i)
+			if v < s.min {
+				v = s.min
+			}
+			if v > s.max {
+				v = s.max
+			}
+			return time.Duration(v)
+		}
+	}
+	return time.Duration(s.max)
+}
+
+// bucketIndex returns the histogram bucket of v: values below 32 map to themselves,
+// larger values to 16 buckets per power of two.
+func bucketIndex(v uint64) int {
+	if v < 32 {
+		return int(v)
+	}
+	shift := bits.Len64(v) - 5
+	return (shift+1)*16 + int(v>>shift) - 16
+}
+
+// bucketValue returns the midpoint of the values in bucket i.
+func bucketValue(i int) int64 {
+	if i < 32 {
+		return int64(i)
+	}
+	shift := i/16 - 1
+	top := int64(16 + i%16)
+	lower := top << shift
+	return lower + (int64(1)<<shift)/2
+}
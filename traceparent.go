@@ -0,0 +1,170 @@
+package ll
+
+import (
+	"context"
+	"encoding/hex"
+	"net/http"
+
+	"github.com/olekukonko/ll/lx"
+)
+
+// W3C Trace Context header names.
+const (
+	TraceparentHeader = "traceparent"
+	TracestateHeader  = "tracestate"
+)
+
+// traceFlagSampled is the W3C "sampled" trace flag, set on traces started locally.
+const traceFlagSampled = 0x01
+
+// loggerContextKey is the context key under which a request-scoped logger is stored.
+type loggerContextKey struct{}
+
+// NewContext returns a copy of ctx carrying logger.
+func NewContext(ctx context.Context, logger *Logger) context.Context {
+	return context.WithValue(ctx, loggerContextKey{}, logger)
+}
+
+// FromContext returns the logger carried by ctx, or the package-level default logger
+// if there is none.
+// Example:
+//
+//	func handler(w http.ResponseWriter, r *http.Request) {
+//	    ll.FromContext(r.Context()).Info("loading cart") // Carries trace_id and span_id
+//	}
+func FromContext(ctx context.Context) *Logger {
+	if ctx != nil {
+		if l, ok := ctx.Value(loggerContextKey{}).(*Logger); ok {
+			return l
+		}
+	}
+	return defaultLogger
+}
+
+// ParseTraceparent parses a W3C traceparent header value. The returned SpanContext
+// identifies the caller's span; ok is false if the value is malformed.
+// Example:
+//
+//	sc, ok := ll.ParseTraceparent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+//	// sc.TraceID == "4bf92f3577b34da6a3ce929d0e0e4736", sc.SpanID == "00f067aa0ba902b7", sc.Flags == 1
+func ParseTraceparent(value string) (sc SpanContext, ok bool) {
+	// version "-" trace-id "-" parent-id "-" trace-flags, all lowercase hex.
+	if len(value) < 55 || value[2] != '-' || value[35] != '-' || value[52] != '-' {
+		return SpanContext{}, false
+	}
+	version, traceID, spanID, flags := value[0:2], value[3:35], value[36:52], value[53:55]
+	if !isLowerHex(version) || version == "ff" || !isLowerHex(traceID) || !isLowerHex(spanID) || !isLowerHex(flags) {
+		return SpanContext{}, false
+	}
+	// Version 00 has exactly four parts; later versions may append more after a dash.
+	if (version == "00" && len(value) != 55) || (len(value) > 55 && value[55] != '-') {
+		return SpanContext{}, false
+	}
+	if traceID == "00000000000000000000000000000000" || spanID == "0000000000000000" {
+		return SpanContext{}, false
+	}
+	var f [1]byte
+	hex.Decode(f[:], []byte(flags))
+	return SpanContext{TraceID: traceID, SpanID: spanID, Flags: f[0]}, true
+}
+
+// Traceparent formats sc as a version 00 W3C traceparent header value, or returns ""
+// if sc is not valid.
+func (sc SpanContext) Traceparent() string {
+	if !sc.IsValid() {
+		return ""
+	}
+	const digits = "0123456789abcdef"
+	return "00-" + sc.TraceID + "-" + sc.SpanID + "-" + string([]byte{digits[sc.Flags>>4], digits[sc.Flags&0x0f]})
+}
+
+// isLowerHex reports whether s consists only of lowercase hex digits.
+func isLowerHex(s string) bool {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// TraceMiddleware returns net/http server middleware that continues the trace described
+// by the request's traceparent and tracestate headers, or starts a new one. Each request
+// gets its own span ID; the request context carries the span (for Logger.Span and
+// Logger.Ctx) and a child of logger with trace_id, span_id and, when the caller sent a
+// traceparent, parent_id. Retrieve it with FromContext.
+// Example:
+//
+//	mux := http.NewServeMux()
+//	mux.HandleFunc("/orders", func(w http.ResponseWriter, r *http.Request) {
+//	    ll.FromContext(r.Context()).Info("listing orders")
+//	})
+//	http.ListenAndServe(":8080", ll.TraceMiddleware(logger)(mux))
+func TraceMiddleware(logger *Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+			sc, ok := ParseTraceparent(r.Header.Get(TraceparentHeader))
+			parentID := ""
+			if ok {
+				parentID = sc.SpanID
+				sc.TraceState = r.Header.Get(TracestateHeader)
+			} else {
+				sc = SpanContext{TraceID: newTraceID(), Flags: traceFlagSampled}
+			}
+			sc.SpanID = newSpanID()
+
+			reqLogger := logger.withContext(traceFields(sc, parentID)...)
+			ctx = ContextWithSpanContext(ctx, sc)
+			ctx = NewContext(ctx, reqLogger)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// traceFields returns the fields identifying a span.
+func traceFields(sc SpanContext, parentID string) lx.Fields {
+	fields := lx.Fields{lx.String(TraceIDKey, sc.TraceID), lx.String(SpanIDKey, sc.SpanID)}
+	if parentID != "" {
+		fields = append(fields, lx.String(ParentIDKey, parentID))
+	}
+	return fields
+}
+
+// TraceTransport wraps an http.RoundTripper (http.DefaultTransport if nil) so that
+// outgoing requests whose context carries a span propagate it downstream in traceparent
+// and tracestate headers. Requests without a span are sent unchanged.
+// Example:
+//
+//	client := &http.Client{Transport: ll.TraceTransport(nil)}
+//	req, _ := http.NewRequestWithContext(ctx, "GET", "http://inventory/items", nil)
+//	client.Do(req) // The inventory service continues the trace
+func TraceTransport(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &traceTransport{next: next}
+}
+
+// traceTransport injects W3C Trace Context headers into outgoing requests.
+type traceTransport struct {
+	next http.RoundTripper
+}
+
+// RoundTrip sets traceparent and tracestate from the request context and sends the request.
+func (t *traceTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	sc, ok := SpanContextFrom(r.Context())
+	if !ok {
+		return t.next.RoundTrip(r)
+	}
+	// A RoundTripper must not modify the caller's request.
+	r = r.Clone(r.Context())
+	r.Header.Set(TraceparentHeader, sc.Traceparent())
+	if sc.TraceState != "" {
+		r.Header.Set(TracestateHeader, sc.TraceState)
+	} else {
+		r.Header.Del(TracestateHeader)
+	}
+	return t.next.RoundTrip(r)
+}
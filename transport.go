@@ -0,0 +1,368 @@
+package ll
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/olekukonko/ll/lx"
+)
+
+// redactedHeader replaces the values of redacted headers.
+const redactedHeader = "[REDACTED]"
+
+// TransportOption configures LogTransport.
+type TransportOption func(*logTransport)
+
+// WithTransportBodies captures request and response bodies of up to max bytes each,
+// logging them as separate entries rendered like Logger.Output. Longer bodies are
+// truncated. Only bodies whose content type passes WithTransportContentTypes are
+// captured; by default, JSON, XML, form and plain text bodies. Response bodies are
+// captured as the caller reads them and logged once read to EOF or closed, so
+// streaming and long-poll responses are not held back.
+func WithTransportBodies(max int) TransportOption {
+	return func(t *logTransport) {
+		t.maxBody = max
+	}
+}
+
+// WithTransportContentTypes sets the media types whose bodies are captured. A type
+// ending in "/" matches every subtype (e.g. "text/"), and "+json" matches every
+// structured JSON type such as "application/problem+json".
+func WithTransportContentTypes(types ...string) TransportOption {
+	return func(t *logTransport) {
+		t.contentTypes = types
+	}
+}
+
+// WithTransportHeaders logs request and response headers as the request_headers and
+// response_headers fields. Authorization, Proxy-Authorization, Cookie and Set-Cookie
+// are always redacted, along with any header named in redact.
+func WithTransportHeaders(redact ...string) TransportOption {
+	return func(t *logTransport) {
+		t.headers = true
+		for _, h := range redact {
+			t.redact[http.CanonicalHeaderKey(h)] = struct{}{}
+		}
+	}
+}
+
+// WithTransportRetries retries failed requests up to max times, waiting backoff before
+// the first retry and doubling it for each further one. A request is retried after a
+// transport error or a 429, 502, 503 or 504 response, if it is idempotent (by method
+// or Idempotency-Key header) and its body can be replayed. The number of retries is
+// logged as the retries field.
+func WithTransportRetries(max int, backoff time.Duration) TransportOption {
+	return func(t *logTransport) {
+		t.retries = max
+		t.backoff = backoff
+	}
+}
+
+// logTransport logs the requests sent through it.
+type logTransport struct {
+	logger       *Logger
+	next         http.RoundTripper
+	maxBody      int
+	contentTypes []string
+	headers      bool
+	redact       map[string]struct{}
+	retries      int
+	backoff      time.Duration
+}
+
+// LogTransport wraps an http.RoundTripper (http.DefaultTransport if nil) so that each
+// outgoing request is logged once it completes, with the message "METHOD url" and
+// the fields method, url (with any password redacted), status, duration, retries (if
+// any) and request_bytes/response_bytes when the lengths are known. Transport errors
+// and 5xx responses are logged at Error, 4xx at Warn and everything else at Info.
+// Entries are logged through logger.Ctx, so they carry the trace IDs of the request
+// context; combine with TraceTransport to also propagate them.
+// Example:
+//
+//	client := &http.Client{Transport: ll.LogTransport(logger, ll.TraceTransport(nil),
+//	    ll.WithTransportBodies(4096),
+//	    ll.WithTransportHeaders("X-Api-Key"),
+//	    ll.WithTransportRetries(2, 100*time.Millisecond),
+//	)}
+//	resp, err := client.Get("https://api.example.com/users/1")
+//	// Output: [app] INFO: GET https://api.example.com/users/1 [method=GET url=... status=200 duration=...]
+func LogTransport(logger *Logger, next http.RoundTripper, opts ...TransportOption) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	t := &logTransport{
+		logger: logger,
+		next:   next,
+		contentTypes: []string{
+			"application/json", "+json", "application/xml", "text/xml",
+			"application/x-www-form-urlencoded", "text/plain",
+		},
+		redact: map[string]struct{}{
+			"Authorization":       {},
+			"Proxy-Authorization": {},
+			"Cookie":              {},
+			"Set-Cookie":          {},
+		},
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t
+}
+
+// RoundTrip sends the request, retrying as configured, and logs the outcome.
+func (t *logTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	var reqTruncated bool
+	if t.maxBody > 0 && r.Body != nil && r.Body != http.NoBody && t.capture(r.Header) && !t.logger.suspend.Load() {
+		// A RoundTripper must not modify the caller's request.
+		r = r.Clone(r.Context())
+		reqBody, reqTruncated, r.Body = peekBody(r.Body, t.maxBody)
+	}
+
+	start := time.Now()
+	retries := 0
+	resp, err := t.next.RoundTrip(r)
+	for retries < t.retries && retryable(resp, err) && replayable(r) {
+		wait := t.backoff << retries
+		if resp != nil {
+			io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+			resp.Body.Close()
+		}
+		if r.GetBody != nil {
+			body, gerr := r.GetBody()
+			if gerr != nil {
+				break
+			}
+			r = r.Clone(r.Context())
+			r.Body = body
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-r.Context().Done():
+			timer.Stop()
+			resp, err = nil, r.Context().Err()
+		case <-timer.C:
+			retries++
+			resp, err = t.next.RoundTrip(r)
+			continue
+		}
+		break
+	}
+	duration := time.Since(start)
+	// Suspended loggers write nothing, as with the logging methods
+	if t.logger.suspend.Load() {
+		return resp, err
+	}
+
+	t.log(r, resp, err, retries, duration)
+	logger := t.logger.Ctx(r.Context())
+	if reqBody != nil {
+		logger.log(lx.LevelInfo, lx.ClassOutput, payloadMessage(r, "request body", reqBody, reqTruncated), nil, false)
+	}
+	if err == nil && t.maxBody > 0 && resp.Body != nil && resp.Body != http.NoBody && t.capture(resp.Header) {
+		// Captured as the caller reads it, so streaming responses are not read ahead
+		resp.Body = &capturedBody{ReadCloser: resp.Body, max: t.maxBody, size: resp.ContentLength, log: func(body []byte, truncated bool) {
+			if !t.logger.suspend.Load() {
+				logger.log(lx.LevelInfo, lx.ClassOutput, payloadMessage(r, "response body", body, truncated), nil, false)
+			}
+		}}
+	}
+	return resp, err
+}
+
+// log writes the summary entry for a completed request.
+func (t *logTransport) log(r *http.Request, resp *http.Response, err error, retries int, duration time.Duration) {
+	url := r.URL.Redacted()
+	fields := make(lx.Fields, 0, 10)
+	fields = append(fields, lx.String("method", r.Method), lx.String("url", url))
+	level := lx.LevelError
+	if resp != nil {
+		level = httpLevel(resp.StatusCode)
+		fields = append(fields, lx.Int("status", resp.StatusCode))
+	}
+	fields = append(fields, lx.Duration("duration", duration))
+	if retries > 0 {
+		fields = append(fields, lx.Int("retries", retries))
+	}
+	if r.ContentLength > 0 {
+		fields = append(fields, lx.Int64("request_bytes", r.ContentLength))
+	}
+	if resp != nil && resp.ContentLength >= 0 {
+		fields = append(fields, lx.Int64("response_bytes", resp.ContentLength))
+	}
+	if t.headers {
+		fields = append(fields, lx.Any("request_headers", t.headerMap(r.Header)))
+		if resp != nil {
+			fields = append(fields, lx.Any("response_headers", t.headerMap(resp.Header)))
+		}
+	}
+	t.logger.Ctx(r.Context()).logErr(level, lx.ClassText, r.Method+" "+url, fields, err, false)
+}
+
+// capture reports whether a body with the given headers should be captured.
+func (t *logTransport) capture(h http.Header) bool {
+	mediaType, _, err := mime.ParseMediaType(h.Get("Content-Type"))
+	if err != nil {
+		return false
+	}
+	for _, ct := range t.contentTypes {
+		switch {
+		case strings.HasPrefix(ct, "+"):
+			if strings.HasSuffix(mediaType, ct) {
+				return true
+			}
+		case strings.HasSuffix(ct, "/"):
+			if strings.HasPrefix(mediaType, ct) {
+				return true
+			}
+		case mediaType == ct:
+			return true
+		}
+	}
+	return false
+}
+
+// headerMap returns the headers as a map of comma-joined values, with sensitive
+// headers redacted.
+func (t *logTransport) headerMap(h http.Header) map[string]string {
+	m := make(map[string]string, len(h))
+	for k, v := range h {
+		if _, ok := t.redact[http.CanonicalHeaderKey(k)]; ok {
+			m[k] = redactedHeader
+			continue
+		}
+		m[k] = strings.Join(v, ", ")
+	}
+	return m
+}
+
+// peekBody reads up to max bytes of body, returning them, whether the body was longer,
+// and a replacement body yielding the full original content.
+func peekBody(body io.ReadCloser, max int) ([]byte, bool, io.ReadCloser) {
+	read, err := io.ReadAll(io.LimitReader(body, int64(max)+1))
+	var rest io.Reader = body
+	if err != nil {
+		rest = errReader{err}
+	}
+	captured, truncated := read, len(read) > max
+	if truncated {
+		captured = read[:max]
+	}
+	return captured, truncated, readCloser{io.MultiReader(bytes.NewReader(read), rest), body}
+}
+
+// capturedBody records up to max bytes of a response body as the caller reads it, and
+// passes them to log once, at EOF or on Close. A body closed before all of its size
+// (or, if unknown, before EOF) was read is logged as truncated.
+type capturedBody struct {
+	io.ReadCloser
+	max  int
+	size int64 // Content length, -1 if unknown
+	log  func(body []byte, truncated bool)
+
+	mu        sync.Mutex // Read and Close may be called concurrently
+	buf       []byte
+	read      int64
+	truncated bool
+	logged    bool
+}
+
+// Read reads from the body, keeping the first max bytes.
+func (c *capturedBody) Read(p []byte) (int, error) {
+	n, err := c.ReadCloser.Read(p)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	keep := min(n, c.max-len(c.buf))
+	c.buf = append(c.buf, p[:keep]...)
+	c.read += int64(n)
+	if n > keep {
+		c.truncated = true
+	}
+	if err == io.EOF {
+		c.flushLocked(c.truncated)
+	}
+	return n, err
+}
+
+// Close closes the body, logging what was captured if it was not read to EOF.
+func (c *capturedBody) Close() error {
+	err := c.ReadCloser.Close()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.flushLocked(c.truncated || c.size < 0 || c.read < c.size)
+	return err
+}
+
+// flushLocked logs the captured bytes once (caller must hold lock).
+func (c *capturedBody) flushLocked(truncated bool) {
+	if c.logged {
+		return
+	}
+	c.logged = true
+	c.log(c.buf, truncated)
+}
+
+// payloadMessage renders a captured body like Logger.Output: a header line followed by
+// the JSON indented with two spaces, or the raw text if the body is not valid JSON.
+func payloadMessage(r *http.Request, what string, body []byte, truncated bool) string {
+	var sb strings.Builder
+	sb.WriteString("[" + r.Method + " " + r.URL.Redacted() + "] " + what + ":\n")
+	var buf bytes.Buffer
+	if !truncated && json.Indent(&buf, body, "  ", "  ") == nil {
+		sb.Write(buf.Bytes())
+	} else {
+		sb.Write(body)
+	}
+	if truncated {
+		sb.WriteString("\n  ... (truncated)")
+	}
+	return sb.String()
+}
+
+// retryable reports whether a request that ended with resp or err should be retried.
+func retryable(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// replayable reports whether r is idempotent and its body can be sent again.
+func replayable(r *http.Request) bool {
+	if r.Body != nil && r.Body != http.NoBody && r.GetBody == nil {
+		return false
+	}
+	switch r.Method {
+	case "", http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	_, ok := r.Header["Idempotency-Key"]
+	return ok
+}
+
+// readCloser combines a reader with the closer of the body it replaces.
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// errReader returns err from every Read.
+type errReader struct {
+	err error
+}
+
+// Read returns the stored error.
+func (r errReader) Read([]byte) (int, error) {
+	return 0, r.err
+}